@@ -229,12 +229,12 @@ func (api *API) ListAccessApplications(ctx context.Context, rc *ResourceContaine
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []AccessApplication{}, &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
+			return append([]AccessApplication{}, applications...), &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
 		}
 
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return []AccessApplication{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]AccessApplication{}, applications...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		applications = append(applications, r.Result...)
 		params.ResultInfo = r.ResultInfo.Next()
@@ -66,12 +66,12 @@ func (api *API) ListAccessCACertificates(ctx context.Context, rc *ResourceContai
 		uri := buildURI(baseURL, params)
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []AccessCACertificate{}, &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
+			return append([]AccessCACertificate{}, accessCACertificates...), &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
 		}
 
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return []AccessCACertificate{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]AccessCACertificate{}, accessCACertificates...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		accessCACertificates = append(accessCACertificates, r.Result...)
 		params.ResultInfo = r.ResultInfo.Next()
@@ -286,12 +286,12 @@ func (api *API) ListAccessGroups(ctx context.Context, rc *ResourceContainer, par
 		uri := buildURI(baseURL, params)
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []AccessGroup{}, &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
+			return append([]AccessGroup{}, accessGroups...), &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
 		}
 
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return []AccessGroup{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]AccessGroup{}, accessGroups...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		accessGroups = append(accessGroups, r.Result...)
 		params.ResultInfo = r.ResultInfo.Next()
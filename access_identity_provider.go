@@ -138,12 +138,12 @@ func (api *API) ListAccessIdentityProviders(ctx context.Context, rc *ResourceCon
 		uri := buildURI(baseURL, params)
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []AccessIdentityProvider{}, &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
+			return append([]AccessIdentityProvider{}, accessProviders...), &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
 		}
 
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return []AccessIdentityProvider{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]AccessIdentityProvider{}, accessProviders...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 
 		accessProviders = append(accessProviders, r.Result...)
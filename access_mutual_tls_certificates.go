@@ -89,12 +89,12 @@ func (api *API) ListAccessMutualTLSCertificates(ctx context.Context, rc *Resourc
 		uri := buildURI(baseURL, params)
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []AccessMutualTLSCertificate{}, &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
+			return append([]AccessMutualTLSCertificate{}, accessCertificates...), &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
 		}
 
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return []AccessMutualTLSCertificate{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]AccessMutualTLSCertificate{}, accessCertificates...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		accessCertificates = append(accessCertificates, r.Result...)
 		params.ResultInfo = r.ResultInfo.Next()
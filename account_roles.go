@@ -70,12 +70,12 @@ func (api *API) ListAccountRoles(ctx context.Context, rc *ResourceContainer, par
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []AccountRole{}, err
+			return append([]AccountRole{}, roles...), err
 		}
 
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return []AccountRole{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]AccountRole{}, roles...), fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		roles = append(roles, r.Result...)
 		params.ResultInfo = r.ResultInfo.Next()
@@ -4,17 +4,22 @@ package cloudflare
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"log"
 	"math"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/goccy/go-json"
@@ -48,12 +53,33 @@ type API struct {
 	BaseURL           string
 	UserAgent         string
 	headers           http.Header
+	defaultHeaders    http.Header
 	httpClient        *http.Client
+	minTLSVersion     uint16
+	dialTimeout       time.Duration
+	headerTimeout     time.Duration
 	authType          int
 	rateLimiter       *rate.Limiter
+	sharedLimiter     *rate.Limiter
 	retryPolicy       RetryPolicy
 	logger            Logger
 	Debug             bool
+	maxQueryLength    int
+	tenantContextKey  interface{}
+
+	correlationIDHeader   string
+	generateCorrelationID bool
+
+	streamLiveInputDefaults  *CreateStreamLiveInputParameters
+	streamAuditHook          func(StreamAuditEvent)
+	streamSignedURLExpiry    time.Duration
+	streamSignedURLMaxExpiry time.Duration
+
+	idempotentStreamLiveInputDeletes bool
+	strictStreamAccessSettings       bool
+	emptyResultErrors                bool
+
+	maxUploadBufferSize int64
 }
 
 // newClient provides shared logic for New and NewWithUserServiceKey.
@@ -70,7 +96,10 @@ func newClient(opts ...Option) (*API, error) {
 			MinRetryDelay: 1 * time.Second,
 			MaxRetryDelay: 30 * time.Second,
 		},
-		logger: silentLogger,
+		logger:              silentLogger,
+		dialTimeout:         defaultDialTimeout,
+		headerTimeout:       defaultResponseHeaderTimeout,
+		maxUploadBufferSize: defaultMaxUploadBufferSize,
 	}
 
 	err := api.parseOptions(opts...)
@@ -78,15 +107,47 @@ func newClient(opts ...Option) (*API, error) {
 		return nil, fmt.Errorf("options parsing failed: %w", err)
 	}
 
-	// Fall back to http.DefaultClient if the package user does not provide
-	// their own.
+	// Fall back to a client using a copy of http.DefaultTransport with the
+	// minimum TLS version and timeouts applied if the package user does not
+	// provide their own.
 	if api.httpClient == nil {
-		api.httpClient = http.DefaultClient
+		api.httpClient = defaultHTTPClient(api.minTLSVersion, api.dialTimeout, api.headerTimeout)
 	}
 
 	return api, nil
 }
 
+// defaultHTTPClient returns the client used when none is provided, which only
+// negotiates TLS versions from minTLSVersion, or TLS 1.2 when it is zero.
+// dialTimeout bounds establishing a connection and headerTimeout bounds
+// waiting for the response headers once the request has been written.
+//
+// When http.DefaultTransport has been replaced by another http.RoundTripper,
+// for example by a mocking library, the client uses it as it is and none of
+// the settings are applied.
+func defaultHTTPClient(minTLSVersion uint16, dialTimeout, headerTimeout time.Duration) *http.Client {
+	if minTLSVersion == 0 {
+		minTLSVersion = tls.VersionTLS12
+	}
+
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return &http.Client{}
+	}
+
+	transport := defaultTransport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.MinVersion = minTLSVersion
+	transport.DialContext = (&net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.ResponseHeaderTimeout = headerTimeout
+	return &http.Client{Transport: transport}
+}
+
 // New creates a new Cloudflare v4 API client.
 func New(key, email string, opts ...Option) (*API, error) {
 	if key == "" || email == "" {
@@ -192,6 +253,16 @@ type APIResponse struct {
 	Headers    http.Header
 }
 
+// ResponseMetadata holds details of the HTTP response a result was decoded
+// from, for callers needing more than the result itself. Messages holds the
+// messages of the response envelope, such as deprecation warnings, which the
+// API may return alongside a successful result.
+type ResponseMetadata struct {
+	StatusCode int
+	Headers    http.Header
+	Messages   []ResponseInfo
+}
+
 func (api *API) makeRequestWithAuthTypeAndHeaders(ctx context.Context, method, uri string, params interface{}, authType int, headers http.Header) ([]byte, error) {
 	res, err := api.makeRequestWithAuthTypeAndHeadersComplete(ctx, method, uri, params, authType, headers)
 	if err != nil {
@@ -206,12 +277,49 @@ func (api *API) makeRequestContextWithHeadersComplete(ctx context.Context, metho
 }
 
 func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, method, uri string, params interface{}, authType int, headers http.Header) (*APIResponse, error) {
+	return api.makeRequestWithAuthTypeAndHeadersTo(ctx, method, uri, params, authType, headers, nil)
+}
+
+// makeRequestContextTo makes a request like makeRequestContextWithHeadersComplete
+// but copies the body of a successful response to w as it arrives instead of
+// reading it into memory, leaving Body of the returned APIResponse empty.
+// Requests are only retried before anything is written to w.
+func (api *API) makeRequestContextTo(ctx context.Context, method, uri string, params interface{}, headers http.Header, w io.Writer) (*APIResponse, error) {
+	return api.makeRequestWithAuthTypeAndHeadersTo(ctx, method, uri, params, api.authType, headers, w)
+}
+
+func (api *API) makeRequestWithAuthTypeAndHeadersTo(ctx context.Context, method, uri string, params interface{}, authType int, headers http.Header, w io.Writer) (*APIResponse, error) {
 	var err error
 	var resp *http.Response
 	var respErr error
 	var respBody []byte
+	var attempts int
+	var retryReason string
+
+	if api.maxQueryLength > 0 {
+		if i := strings.Index(uri, "?"); i >= 0 && len(uri)-i-1 > api.maxQueryLength {
+			return nil, fmt.Errorf("%w: %d characters exceeds the limit of %d", ErrQueryTooLong, len(uri)-i-1, api.maxQueryLength)
+		}
+	}
+
+	if api.generateCorrelationID && CorrelationID(ctx) == "" {
+		ctx = WithCorrelationID(ctx, newCorrelationID())
+	}
+
+	// Buffers are replayed from their contents on retries. Streamed bodies
+	// can only be sent once, so those requests are not retried.
+	var streamed bool
+	switch body := params.(type) {
+	case *bytes.Buffer:
+		params = body.Bytes()
+	case streamedBody:
+		params = body.Reader
+		streamed = true
+	}
 
 	for i := 0; i <= api.retryPolicy.MaxRetries; i++ {
+		attempts = i + 1
+
 		var reqBody io.Reader
 		if params != nil {
 			if r, ok := params.(io.Reader); ok {
@@ -238,7 +346,7 @@ func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, m
 				sleepDuration = api.retryPolicy.MaxRetryDelay
 			}
 			// useful to do some simple logging here, maybe introduce levels later
-			api.logger.Printf("Sleeping %s before retry attempt number %d for request %s %s", sleepDuration.String(), i, method, uri)
+			api.logger.Printf("Sleeping %s before retry attempt number %d for request %s %s after %s%s", sleepDuration.String(), i, method, uri, retryReason, api.logSuffix(ctx))
 
 			select {
 			case <-time.After(sleepDuration):
@@ -247,9 +355,9 @@ func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, m
 			}
 		}
 
-		err = api.rateLimiter.Wait(ctx)
+		err = api.waitRateLimit(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error caused by request rate limiting: %w", err)
+			return nil, err
 		}
 
 		resp, respErr = api.request(ctx, method, uri, reqBody, authType, headers)
@@ -262,6 +370,12 @@ func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, m
 		// retry if the server is rate limiting us or if it failed
 		// assumes server operations are rolled back on failure
 		if respErr != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			if respErr != nil {
+				retryReason = respErr.Error()
+			} else {
+				retryReason = fmt.Sprintf("HTTP %d", resp.StatusCode)
+			}
+
 			if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
 				respErr = errors.New("exceeded available rate limit retries")
 			}
@@ -269,10 +383,20 @@ func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, m
 			if respErr == nil {
 				respErr = fmt.Errorf("received %s response (HTTP %d), please try again later", strings.ToLower(http.StatusText(resp.StatusCode)), resp.StatusCode)
 			}
+			if streamed {
+				break
+			}
 			continue
 		} else {
-			respBody, err = io.ReadAll(resp.Body)
 			defer resp.Body.Close()
+			if w != nil && resp.StatusCode < http.StatusBadRequest {
+				if _, err := io.Copy(w, resp.Body); err != nil {
+					return nil, fmt.Errorf("could not copy response body: %w", err)
+				}
+				break
+			}
+
+			respBody, err = readResponseBody(resp.Body)
 			if err != nil {
 				return nil, fmt.Errorf("could not read response body: %w", err)
 			}
@@ -321,6 +445,7 @@ func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, m
 			ErrorCodes:    errCodes,
 			ErrorMessages: errMsgs,
 			Messages:      errBody.Messages,
+			Attempts:      attempts,
 		}
 
 		switch resp.StatusCode {
@@ -350,6 +475,20 @@ func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, m
 	}, nil
 }
 
+// waitRateLimit blocks until both the rate limit of the client and the shared
+// rate limit, if there is one, allow a request.
+func (api *API) waitRateLimit(ctx context.Context) error {
+	if err := api.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("error caused by request rate limiting: %w", err)
+	}
+	if api.sharedLimiter != nil {
+		if err := api.sharedLimiter.Wait(ctx); err != nil {
+			return fmt.Errorf("error caused by shared request rate limiting: %w", err)
+		}
+	}
+	return nil
+}
+
 // request makes a HTTP request to the given API endpoint, returning the raw
 // *http.Response, or an error if one occurred. The caller is responsible for
 // closing the response body.
@@ -360,6 +499,7 @@ func (api *API) request(ctx context.Context, method, uri string, reqBody io.Read
 	}
 
 	combinedHeaders := make(http.Header)
+	copyHeader(combinedHeaders, api.defaultHeaders)
 	copyHeader(combinedHeaders, api.headers)
 	copyHeader(combinedHeaders, headers)
 	req.Header = combinedHeaders
@@ -383,6 +523,10 @@ func (api *API) request(ctx context.Context, method, uri string, reqBody io.Read
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	if id := CorrelationID(ctx); id != "" && api.correlationIDHeader != "" {
+		req.Header.Set(api.correlationIDHeader, id)
+	}
+
 	if api.Debug {
 		dump, err := httputil.DumpRequestOut(req, true)
 		if err != nil {
@@ -397,7 +541,7 @@ func (api *API) request(ctx context.Context, method, uri string, reqBody io.Read
 				dump = valueRegex.ReplaceAll(dump, []byte("[redacted]"))
 			}
 		}
-		log.Printf("\n%s", string(dump))
+		log.Printf("%s\n%s", api.logSuffix(ctx), string(dump))
 	}
 
 	resp, err := api.httpClient.Do(req)
@@ -410,12 +554,118 @@ func (api *API) request(ctx context.Context, method, uri string, reqBody io.Read
 		if err != nil {
 			return resp, err
 		}
-		log.Printf("\n%s", string(dump))
+		log.Printf("%s\n%s", api.logSuffix(ctx), string(dump))
 	}
 
 	return resp, nil
 }
 
+// tenantID returns the value stored in ctx under the key set with
+// UsingTenantContextKey, or an empty string when there is none.
+func (api *API) tenantID(ctx context.Context) string {
+	if api.tenantContextKey == nil {
+		return ""
+	}
+	if v := ctx.Value(api.tenantContextKey); v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// logSuffix returns the tenant ID and correlation ID of ctx formatted to be
+// appended to a log line, or an empty string when there are none.
+func (api *API) logSuffix(ctx context.Context) string {
+	var parts []string
+	if tenant := api.tenantID(ctx); tenant != "" {
+		parts = append(parts, "tenant "+tenant)
+	}
+	if id := CorrelationID(ctx); id != "" {
+		parts = append(parts, "correlation ID "+id)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+type correlationIDContextKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID of a
+// request. The ID is included in log lines and, when enabled with
+// UsingCorrelationIDHeader, sent as a request header.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or an empty string
+// when there is none.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDContextKey{}).(string)
+	return id
+}
+
+// newCorrelationID returns a random 128-bit correlation ID encoded as hex.
+func newCorrelationID() string {
+	b := make([]byte, 16)
+	rand.Read(b) //nolint:errcheck
+	return hex.EncodeToString(b)
+}
+
+// defaultDialTimeout and defaultResponseHeaderTimeout are the timeouts of the
+// default HTTP client unless UsingDialTimeout or UsingResponseHeaderTimeout
+// are used.
+const (
+	defaultDialTimeout           = 30 * time.Second
+	defaultResponseHeaderTimeout = 60 * time.Second
+)
+
+// streamedBody is a request body which is read as it is sent, such as a pipe,
+// and so can't be sent again. Requests with a streamed body are not retried.
+type streamedBody struct {
+	io.Reader
+}
+
+// defaultMaxUploadBufferSize is the size above which file uploads are streamed
+// rather than buffered for retries, unless UsingMaxUploadBufferSize is used.
+const defaultMaxUploadBufferSize = 64 << 20
+
+// maxPooledResponseBufferSize is the capacity above which response buffers are
+// not returned to responseBufferPool, so that a single large response does
+// not keep its memory alive.
+const maxPooledResponseBufferSize = 1 << 20
+
+// responseBufferPool holds the buffers response bodies are read into.
+var responseBufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// readResponseBody reads r into a pooled buffer and returns a copy of its
+// content, allocated once at its final size rather than grown while reading.
+// The returned slice is owned by the caller.
+func readResponseBody(r io.Reader) ([]byte, error) {
+	buf := responseBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledResponseBufferSize {
+			responseBufferPool.Put(buf)
+		}
+	}()
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+
+	body := make([]byte, buf.Len())
+	copy(body, buf.Bytes())
+	return body, nil
+}
+
+// reservedHeaders are the credential headers set from the client's
+// authentication, which UsingDefaultHeaders cannot set.
+var reservedHeaders = []string{"Authorization", "X-Auth-Key", "X-Auth-Email", "X-Auth-User-Service-Key"}
+
 // copyHeader copies all headers for `source` and sets them on `target`.
 // based on https://godoc.org/github.com/golang/gddo/httputil/header#Copy
 func copyHeader(target, source http.Header) {
@@ -429,6 +679,10 @@ func copyHeader(target, source http.Header) {
 type ResponseInfo struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+
+	// ErrorChain is the list of underlying errors that caused this one, if
+	// any. Each entry may itself have an error chain.
+	ErrorChain []ResponseInfo `json:"error_chain,omitempty"`
 }
 
 // Response is a template.  There will also be a result struct.  There will be a
@@ -446,6 +700,10 @@ type ResultInfoCursors struct {
 }
 
 // ResultInfo contains metadata about the Response.
+//
+// List methods which fetch every page automatically stop at the first page
+// which fails and return the items of the pages fetched so far along with the
+// error.
 type ResultInfo struct {
 	Page       int               `json:"page" url:"page,omitempty"`
 	PerPage    int               `json:"per_page" url:"per_page,omitempty"`
@@ -2,14 +2,21 @@ package cloudflare
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/time/rate"
 )
 
 var (
@@ -194,6 +201,364 @@ func TestClient_RetryCanSucceedAfterErrors(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// recordingLogger records every formatted log line.
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestClient_RetryIsLogged(t *testing.T) {
+	logger := &recordingLogger{}
+	setup(UsingRetryPolicy(3, 0, 0), UsingLogger(logger))
+	defer teardown()
+
+	requestsReceived := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		requestsReceived++
+		w.Header().Set("content-type", "application/json")
+		if requestsReceived <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {}}`)
+	})
+
+	_, err := client.StreamGetVideo(context.Background(), StreamParameters{AccountID: testAccountID, VideoID: testVideoID})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"Sleeping 0s before retry attempt number 1 for request GET /accounts/" + testAccountID + "/stream/" + testVideoID + " after HTTP 503",
+		"Sleeping 0s before retry attempt number 2 for request GET /accounts/" + testAccountID + "/stream/" + testVideoID + " after HTTP 503",
+	}, logger.lines)
+}
+
+type testTenantKey struct{}
+
+func TestClient_RetryLogIncludesTenantID(t *testing.T) {
+	logger := &recordingLogger{}
+	var events []StreamAuditEvent
+	setup(UsingRetryPolicy(1, 0, 0), UsingLogger(logger), UsingTenantContextKey(testTenantKey{}), UsingStreamAuditHook(func(event StreamAuditEvent) {
+		events = append(events, event)
+	}))
+	defer teardown()
+
+	requestsReceived := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		requestsReceived++
+		w.Header().Set("content-type", "application/json")
+		if requestsReceived == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {}}`)
+	})
+
+	ctx := context.WithValue(context.Background(), testTenantKey{}, "tenant-42")
+	err := client.StreamDeleteVideo(ctx, StreamParameters{AccountID: testAccountID, VideoID: testVideoID})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"Sleeping 0s before retry attempt number 1 for request DELETE /accounts/" + testAccountID + "/stream/" + testVideoID + " after HTTP 503 (tenant tenant-42)",
+	}, logger.lines)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "tenant-42", events[0].TenantID)
+	}
+
+	logger.lines = nil
+	requestsReceived = 0
+	err = client.StreamDeleteVideo(context.Background(), StreamParameters{AccountID: testAccountID, VideoID: testVideoID})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"Sleeping 0s before retry attempt number 1 for request DELETE /accounts/" + testAccountID + "/stream/" + testVideoID + " after HTTP 503",
+	}, logger.lines)
+	if assert.Len(t, events, 2) {
+		assert.Empty(t, events[1].TenantID)
+	}
+}
+
+func TestReadResponseBody(t *testing.T) {
+	first, err := readResponseBody(strings.NewReader("first response"))
+	require.NoError(t, err)
+
+	second, err := readResponseBody(strings.NewReader("second"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "first response", string(first), "a body should not be modified once the buffer is reused")
+	assert.Equal(t, "second", string(second))
+	assert.Equal(t, len(second), cap(second))
+
+	large, err := readResponseBody(strings.NewReader(strings.Repeat("a", 2*maxPooledResponseBufferSize)))
+	require.NoError(t, err)
+	assert.Len(t, large, 2*maxPooledResponseBufferSize)
+}
+
+func BenchmarkReadResponseBody(b *testing.B) {
+	body := strings.Repeat(`{"uid": "`+testLiveInputID+`", "meta": {"name": "test stream"}},`, 200)
+
+	b.Run("io.ReadAll", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := io.ReadAll(strings.NewReader(body)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := readResponseBody(strings.NewReader(body)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestClient_DefaultHeaders(t *testing.T) {
+	_, err := New("deadbeef", "cloudflare@example.org", UsingDefaultHeaders(map[string]string{"authorization": "Bearer other"}))
+	assert.Error(t, err, "reserved headers should be rejected")
+
+	headers := make(http.Header)
+	headers.Set("Authorization", "Bearer other")
+	headers.Set("X-Proxy", "proxy-1")
+	setup(Headers(headers), UsingDefaultHeaders(map[string]string{"X-Tenant": "tenant-42", "X-Proxy": "default", "Content-Type": "text/plain"}))
+	defer teardown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tenant-42", r.Header.Get("X-Tenant"))
+		assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+		assert.Equal(t, "proxy-1", r.Header.Get("X-Proxy"), "headers set with Headers take precedence")
+		assert.Equal(t, "deadbeef", r.Header.Get("X-Auth-Key"))
+		assert.Equal(t, "Bearer other", r.Header.Get("Authorization"), "credentials set with Headers are kept")
+	})
+	client.UserDetails(context.Background()) //nolint
+
+	// headers set by a request take precedence
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/captions/en", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tenant-42", r.Header.Get("X-Tenant"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data"))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"language": "en"}}`)
+	})
+	_, err = client.UploadStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoCaptionParameters{
+		VideoID:  testVideoID,
+		Language: "en",
+		Caption:  []byte("WEBVTT\n"),
+	})
+	assert.NoError(t, err)
+}
+
+func TestClient_MinTLSVersion(t *testing.T) {
+	minVersion := func(api *API) uint16 {
+		transport, ok := api.httpClient.Transport.(*http.Transport)
+		require.True(t, ok, "expected the default client to use an *http.Transport")
+		require.NotNil(t, transport.TLSClientConfig)
+		return transport.TLSClientConfig.MinVersion
+	}
+
+	api, err := New("deadbeef", "cloudflare@example.org")
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), minVersion(api))
+
+	api, err = New("deadbeef", "cloudflare@example.org", UsingMinTLSVersion(tls.VersionTLS13))
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), minVersion(api))
+
+	_, err = New("deadbeef", "cloudflare@example.org", UsingMinTLSVersion(0x0200))
+	assert.Error(t, err)
+
+	custom := &http.Client{}
+	api, err = New("deadbeef", "cloudflare@example.org", HTTPClient(custom), UsingMinTLSVersion(tls.VersionTLS13))
+	require.NoError(t, err)
+	assert.Same(t, custom, api.httpClient)
+	assert.Nil(t, custom.Transport, "a provided client should not be modified")
+}
+
+func TestClient_ReplacedDefaultTransport(t *testing.T) {
+	var requests int
+	original := http.DefaultTransport
+	http.DefaultTransport = RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"success": true, "errors": [], "messages": [], "result": {"id": "mocked"}}`)),
+			Request:    r,
+		}, nil
+	})
+	defer func() { http.DefaultTransport = original }()
+
+	api, err := New("deadbeef", "cloudflare@example.org", UsingMinTLSVersion(tls.VersionTLS13))
+	require.NoError(t, err)
+	assert.Nil(t, api.httpClient.Transport, "the replaced default transport should be used as it is")
+
+	user, err := api.UserDetails(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "mocked", user.ID)
+	assert.Equal(t, 1, requests)
+}
+
+func TestClient_Timeouts(t *testing.T) {
+	api, err := New("deadbeef", "cloudflare@example.org")
+	require.NoError(t, err)
+	transport, ok := api.httpClient.Transport.(*http.Transport)
+	require.True(t, ok, "expected the default client to use an *http.Transport")
+	assert.Equal(t, 60*time.Second, transport.ResponseHeaderTimeout)
+	assert.NotNil(t, transport.DialContext)
+
+	_, err = New("deadbeef", "cloudflare@example.org", UsingDialTimeout(-time.Second))
+	assert.Error(t, err)
+	_, err = New("deadbeef", "cloudflare@example.org", UsingResponseHeaderTimeout(-time.Second))
+	assert.Error(t, err)
+
+	setup(UsingDialTimeout(time.Minute), UsingResponseHeaderTimeout(10*time.Millisecond))
+	defer teardown()
+
+	transport, ok = client.httpClient.Transport.(*http.Transport)
+	require.True(t, ok, "expected the default client to use an *http.Transport")
+	assert.Equal(t, 10*time.Millisecond, transport.ResponseHeaderTimeout)
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	})
+	_, err = client.UserDetails(context.Background())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "timeout awaiting response headers")
+	}
+
+	// A connection can't be established within a nanosecond, even though the
+	// response timeout is long enough.
+	api, err = New("deadbeef", "cloudflare@example.org", UsingRetryPolicy(0, 0, 0), UsingDialTimeout(time.Nanosecond), UsingResponseHeaderTimeout(time.Minute))
+	require.NoError(t, err)
+	api.BaseURL = server.URL
+	_, err = api.UserDetails(context.Background())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "dial tcp")
+	}
+}
+
+func TestClient_SharedRateLimiter(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Every(20*time.Millisecond), 1)
+	setup(UsingSharedRateLimiter(limiter))
+	defer teardown()
+
+	var requests int
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {}}`)
+	})
+
+	other, err := New("deadbeef", "cloudflare@example.org", UsingRateLimit(100000), UsingSharedRateLimiter(limiter))
+	require.NoError(t, err)
+	other.BaseURL = server.URL
+
+	// Both clients draw from the same limiter, so six requests take at least
+	// five intervals even though each client has a very high rate limit.
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.UserDetails(context.Background())
+		require.NoError(t, err)
+		_, err = other.UserDetails(context.Background())
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, 6, requests)
+
+	// Waiting on the shared limiter respects the request context.
+	slow := rate.NewLimiter(rate.Every(time.Hour), 1)
+	slow.Allow()
+	api, err := New("deadbeef", "cloudflare@example.org", UsingRateLimit(100000), UsingSharedRateLimiter(slow))
+	require.NoError(t, err)
+	api.BaseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = api.UserDetails(ctx)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "shared request rate limiting")
+	}
+	assert.Equal(t, 6, requests)
+}
+
+func TestClient_RetryReaderBodies(t *testing.T) {
+	setup(UsingRetryPolicy(1, 0, 0))
+	defer teardown()
+
+	var requests int
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {}}`)
+	})
+
+	// Readers other than streamed bodies are still retried.
+	_, err := client.makeRequestContextWithHeaders(context.Background(), http.MethodPost, "/upload", strings.NewReader("content"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	requests = 0
+	_, err = client.makeRequestContextWithHeaders(context.Background(), http.MethodPost, "/upload", streamedBody{strings.NewReader("content")}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests, "a streamed body should not be retried")
+}
+
+func TestClient_CorrelationID(t *testing.T) {
+	logger := &recordingLogger{}
+	setup(UsingRetryPolicy(1, 0, 0), UsingLogger(logger), UsingCorrelationIDHeader("", true))
+	defer teardown()
+
+	var received []string
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("X-Correlation-Id"))
+		w.Header().Set("content-type", "application/json")
+		if len(received)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {}}`)
+	})
+
+	ctx := WithCorrelationID(context.Background(), "req-123")
+	_, err := client.StreamGetVideo(ctx, StreamParameters{AccountID: testAccountID, VideoID: testVideoID})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"req-123", "req-123"}, received)
+	assert.Equal(t, []string{
+		"Sleeping 0s before retry attempt number 1 for request GET /accounts/" + testAccountID + "/stream/" + testVideoID + " after HTTP 503 (correlation ID req-123)",
+	}, logger.lines)
+
+	logger.lines = nil
+	received = nil
+	_, err = client.StreamGetVideo(context.Background(), StreamParameters{AccountID: testAccountID, VideoID: testVideoID})
+	assert.NoError(t, err)
+	if assert.Len(t, received, 2) {
+		assert.Regexp(t, "^[0-9a-f]{32}$", received[0])
+		assert.Equal(t, received[0], received[1], "expected the generated ID to be kept across retries")
+		assert.Equal(t, []string{
+			"Sleeping 0s before retry attempt number 1 for request GET /accounts/" + testAccountID + "/stream/" + testVideoID + " after HTTP 503 (correlation ID " + received[0] + ")",
+		}, logger.lines)
+	}
+}
+
+func TestClient_CorrelationIDHeaderDisabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("X-Correlation-Id"))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {}}`)
+	})
+
+	_, err := client.StreamGetVideo(WithCorrelationID(context.Background(), "req-123"), StreamParameters{AccountID: testAccountID, VideoID: testVideoID})
+	assert.NoError(t, err)
+}
+
 func TestClient_RetryReturnsPersistentErrorResponse(t *testing.T) {
 	setup(UsingRetryPolicy(2, 0, 1))
 	defer teardown()
@@ -468,6 +833,32 @@ func TestClient_ContextIsPassedToRequest(t *testing.T) {
 	cfClient.ListZonesContext(ctx) //nolint
 }
 
+func TestClient_MaxQueryLength(t *testing.T) {
+	setup(UsingMaxQueryLength(100))
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": []}`)
+	})
+
+	_, err := client.StreamListVideos(context.Background(), StreamListParameters{
+		AccountID: testAccountID,
+		Search:    strings.Repeat("a", 200),
+	})
+	assert.True(t, errors.Is(err, ErrQueryTooLong))
+	assert.Equal(t, 0, requests, "an over-long query must not be sent")
+
+	_, err = client.StreamListVideos(context.Background(), StreamListParameters{
+		AccountID: testAccountID,
+		Search:    "short",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+}
+
 func TestErrorFromResponseWithUnmarshalingError(t *testing.T) {
 	setup()
 	defer teardown()
@@ -359,12 +359,12 @@ func (api *API) ListDeviceSettingsPolicies(ctx context.Context, rc *ResourceCont
 		uri := buildURI(fmt.Sprintf("/%s/%s/devices/policies", rc.Level, rc.Identifier), params)
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return nil, nil, err
+			return policies, nil, err
 		}
 		var r ListDeviceSettingsPoliciesResponse
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return nil, nil, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return policies, nil, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		policies = append(policies, r.Result...)
 		lastResultInfo = r.ResultInfo
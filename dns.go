@@ -251,12 +251,12 @@ func (api *API) ListDNSRecords(ctx context.Context, rc *ResourceContainer, param
 		uri := buildURI(fmt.Sprintf("/zones/%s/dns_records", rc.Identifier), params)
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []DNSRecord{}, &ResultInfo{}, err
+			return append([]DNSRecord{}, records...), &ResultInfo{}, err
 		}
 		var listResponse DNSListResponse
 		err = json.Unmarshal(res, &listResponse)
 		if err != nil {
-			return []DNSRecord{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]DNSRecord{}, records...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		records = append(records, listResponse.Result...)
 		lastResultInfo = listResponse.ResultInfo
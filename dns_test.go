@@ -416,6 +416,40 @@ func TestListDNSRecordsPagination(t *testing.T) {
 	}
 }
 
+func TestListDNSRecordsPaginationPartialResults(t *testing.T) {
+	pageSize := listDNSRecordsDefaultPageSize
+	t.Cleanup(func() { listDNSRecordsDefaultPageSize = pageSize })
+	listDNSRecordsDefaultPageSize = 3
+
+	setup()
+	defer teardown()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		if r.URL.Query().Get("page") != "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"success": false, "errors": [{"code": 1000, "message": "page unavailable"}], "messages": [], "result": null}`)
+			return
+		}
+		fmt.Fprint(w, loadFixture("dns", "list_page_1"))
+	}
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", handler)
+
+	actual, _, err := client.ListDNSRecords(context.Background(), ZoneIdentifier(testZoneID), ListDNSRecordsParams{})
+	assert.Error(t, err)
+	assert.Len(t, actual, 3, "records of the first page should be returned with the error")
+
+	mux.HandleFunc("/zones/unavailable/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 1000, "message": "page unavailable"}], "messages": [], "result": null}`)
+	})
+	actual, _, err = client.ListDNSRecords(context.Background(), ZoneIdentifier("unavailable"), ListDNSRecordsParams{})
+	assert.Error(t, err)
+	assert.Equal(t, []DNSRecord{}, actual, "an empty slice rather than nil should be returned when the first page fails")
+}
+
 func TestGetDNSRecord(t *testing.T) {
 	setup()
 	defer teardown()
@@ -67,11 +67,11 @@ func (api *API) ListEmailRoutingDestinationAddresses(ctx context.Context, rc *Re
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []EmailRoutingDestinationAddress{}, &ResultInfo{}, err
+			return append([]EmailRoutingDestinationAddress{}, addresses...), &ResultInfo{}, err
 		}
 		err = json.Unmarshal(res, &eResponse)
 		if err != nil {
-			return []EmailRoutingDestinationAddress{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]EmailRoutingDestinationAddress{}, addresses...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		addresses = append(addresses, eResponse.Result...)
 		params.ResultInfo = eResponse.ResultInfo.Next()
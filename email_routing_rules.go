@@ -109,12 +109,12 @@ func (api *API) ListEmailRoutingRules(ctx context.Context, rc *ResourceContainer
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []EmailRoutingRule{}, &ResultInfo{}, err
+			return append([]EmailRoutingRule{}, rules...), &ResultInfo{}, err
 		}
 
 		err = json.Unmarshal(res, &rResponse)
 		if err != nil {
-			return []EmailRoutingRule{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]EmailRoutingRule{}, rules...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 
 		rules = append(rules, rResponse.Result...)
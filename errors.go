@@ -16,6 +16,7 @@ const (
 	errUnmarshalErrorBody                     = "error unmarshalling the JSON response error body"
 	errRequestNotSuccessful                   = "error reported by API"
 	errMissingAccountID                       = "required missing account ID"
+	errInvalidAccountID                       = "invalid account ID"
 	errMissingZoneID                          = "required missing zone ID"
 	errMissingAccountOrZoneID                 = "either account ID or zone ID must be provided"
 	errAccountIDAndZoneIDAreMutuallyExclusive = "account ID and zone ID are mutually exclusive"
@@ -28,6 +29,8 @@ const (
 	errInvalidZoneIdentifer                   = "invalid zone identifier: %s"
 	errAPIKeysAndTokensAreMutuallyExclusive   = "API keys and tokens are mutually exclusive" //nolint:gosec
 	errMissingCredentials                     = "no credentials provided"
+	errQueryTooLong                           = "query string exceeds the maximum length, consider using narrower filters"
+	errEmptyResult                            = "API reported success with an empty result"
 
 	errInvalidResourceContainerAccess        = "requested resource container (%q) is not supported for this endpoint"
 	errRequiredAccountLevelResourceContainer = "this endpoint requires using an account level resource container and identifiers"
@@ -38,10 +41,13 @@ var (
 	ErrAPIKeysAndTokensAreMutuallyExclusive   = errors.New(errAPIKeysAndTokensAreMutuallyExclusive)
 	ErrMissingCredentials                     = errors.New(errMissingCredentials)
 	ErrMissingAccountID                       = errors.New(errMissingAccountID)
+	ErrInvalidAccountID                       = errors.New(errInvalidAccountID)
 	ErrMissingZoneID                          = errors.New(errMissingZoneID)
 	ErrAccountIDOrZoneIDAreRequired           = errors.New(errMissingAccountOrZoneID)
 	ErrAccountIDAndZoneIDAreMutuallyExclusive = errors.New(errAccountIDAndZoneIDAreMutuallyExclusive)
 	ErrMissingResourceIdentifier              = errors.New(errMissingResourceIdentifier)
+	ErrQueryTooLong                           = errors.New(errQueryTooLong)
+	ErrEmptyResult                            = errors.New(errEmptyResult)
 
 	ErrRequiredAccountLevelResourceContainer = errors.New(errRequiredAccountLevelResourceContainer)
 	ErrRequiredZoneLevelResourceContainer    = errors.New(errRequiredZoneLevelResourceContainer)
@@ -79,6 +85,10 @@ type Error struct {
 
 	// RayID is the internal identifier for the request that was made.
 	RayID string
+
+	// Attempts is the number of times the request was sent, including
+	// retries, when the error response was received.
+	Attempts int
 }
 
 func (e Error) Error() string {
@@ -136,6 +146,10 @@ func (e RequestError) ErrorMessages() []string {
 	return e.cloudflareError.ErrorMessages
 }
 
+func (e RequestError) ErrorChain() []ResponseInfo {
+	return e.cloudflareError.ErrorChain()
+}
+
 func (e RequestError) InternalErrorCodeIs(code int) bool {
 	return e.cloudflareError.InternalErrorCodeIs(code)
 }
@@ -180,6 +194,10 @@ func (e RatelimitError) ErrorMessages() []string {
 	return e.cloudflareError.ErrorMessages
 }
 
+func (e RatelimitError) ErrorChain() []ResponseInfo {
+	return e.cloudflareError.ErrorChain()
+}
+
 func (e RatelimitError) InternalErrorCodeIs(code int) bool {
 	return e.cloudflareError.InternalErrorCodeIs(code)
 }
@@ -219,6 +237,10 @@ func (e ServiceError) ErrorMessages() []string {
 	return e.cloudflareError.ErrorMessages
 }
 
+func (e ServiceError) ErrorChain() []ResponseInfo {
+	return e.cloudflareError.ErrorChain()
+}
+
 func (e ServiceError) InternalErrorCodeIs(code int) bool {
 	return e.cloudflareError.InternalErrorCodeIs(code)
 }
@@ -258,6 +280,10 @@ func (e AuthenticationError) ErrorMessages() []string {
 	return e.cloudflareError.ErrorMessages
 }
 
+func (e AuthenticationError) ErrorChain() []ResponseInfo {
+	return e.cloudflareError.ErrorChain()
+}
+
 func (e AuthenticationError) InternalErrorCodeIs(code int) bool {
 	return e.cloudflareError.InternalErrorCodeIs(code)
 }
@@ -297,6 +323,10 @@ func (e AuthorizationError) ErrorMessages() []string {
 	return e.cloudflareError.ErrorMessages
 }
 
+func (e AuthorizationError) ErrorChain() []ResponseInfo {
+	return e.cloudflareError.ErrorChain()
+}
+
 func (e AuthorizationError) InternalErrorCodeIs(code int) bool {
 	return e.cloudflareError.InternalErrorCodeIs(code)
 }
@@ -336,6 +366,10 @@ func (e NotFoundError) ErrorMessages() []string {
 	return e.cloudflareError.ErrorMessages
 }
 
+func (e NotFoundError) ErrorChain() []ResponseInfo {
+	return e.cloudflareError.ErrorChain()
+}
+
 func (e NotFoundError) InternalErrorCodeIs(code int) bool {
 	return e.cloudflareError.InternalErrorCodeIs(code)
 }
@@ -379,6 +413,23 @@ func (e *Error) InternalErrorCodeIs(code int) bool {
 	return false
 }
 
+// ErrorChain returns all of the errors in the error chains of `e.Errors`,
+// flattened depth first. The top level errors themselves are not included.
+func (e *Error) ErrorChain() []ResponseInfo {
+	var chain []ResponseInfo
+	var walk func(errs []ResponseInfo)
+	walk = func(errs []ResponseInfo) {
+		for _, err := range errs {
+			chain = append(chain, err)
+			walk(err.ErrorChain)
+		}
+	}
+	for _, err := range e.Errors {
+		walk(err.ErrorChain)
+	}
+	return chain
+}
+
 // ErrorMessageContains returns a boolean whether or not a substring exists in
 // any of the `e.ErrorMessages` slice entries.
 func (e *Error) ErrorMessageContains(s string) bool {
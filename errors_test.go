@@ -1,9 +1,13 @@
 package cloudflare
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestError_Error(t *testing.T) {
@@ -50,3 +54,52 @@ func TestError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestError_ErrorChain(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{
+  "success": false,
+  "errors": [
+    {
+      "code": 10005,
+      "message": "Bad request",
+      "error_chain": [
+        {
+          "code": 10010,
+          "message": "Invalid meta",
+          "error_chain": [
+            {"code": 10011, "message": "meta.name is too long"}
+          ]
+        },
+        {"code": 10020, "message": "Invalid thumbnail timestamp"}
+      ]
+    },
+    {"code": 10030, "message": "Invalid creator"}
+  ],
+  "messages": [],
+  "result": null
+}`)
+	})
+
+	_, err := client.StreamGetVideo(context.Background(), StreamParameters{AccountID: testAccountID, VideoID: testVideoID})
+
+	var requestErr *RequestError
+	require.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, []int{10005, 10030}, requestErr.ErrorCodes())
+	assert.Equal(t, []ResponseInfo{
+		{
+			Code:    10010,
+			Message: "Invalid meta",
+			ErrorChain: []ResponseInfo{
+				{Code: 10011, Message: "meta.name is too long"},
+			},
+		},
+		{Code: 10011, Message: "meta.name is too long"},
+		{Code: 10020, Message: "Invalid thumbnail timestamp"},
+	}, requestErr.ErrorChain())
+}
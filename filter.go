@@ -130,12 +130,12 @@ func (api *API) Filters(ctx context.Context, rc *ResourceContainer, params Filte
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []Filter{}, &ResultInfo{}, err
+			return append([]Filter{}, filters...), &ResultInfo{}, err
 		}
 
 		err = json.Unmarshal(res, &fResponse)
 		if err != nil {
-			return []Filter{}, &ResultInfo{}, fmt.Errorf("failed to unmarshal filters JSON data: %w", err)
+			return append([]Filter{}, filters...), &ResultInfo{}, fmt.Errorf("failed to unmarshal filters JSON data: %w", err)
 		}
 
 		filters = append(filters, fResponse.Result...)
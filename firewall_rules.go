@@ -96,12 +96,12 @@ func (api *API) FirewallRules(ctx context.Context, rc *ResourceContainer, params
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []FirewallRule{}, &ResultInfo{}, err
+			return append([]FirewallRule{}, firewallRules...), &ResultInfo{}, err
 		}
 
 		err = json.Unmarshal(res, &fResponse)
 		if err != nil {
-			return []FirewallRule{}, &ResultInfo{}, fmt.Errorf("failed to unmarshal filters JSON data: %w", err)
+			return append([]FirewallRule{}, firewallRules...), &ResultInfo{}, fmt.Errorf("failed to unmarshal filters JSON data: %w", err)
 		}
 
 		firewallRules = append(firewallRules, fResponse.Result...)
@@ -172,12 +172,12 @@ func (api *API) ListZoneLockdowns(ctx context.Context, rc *ResourceContainer, pa
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []ZoneLockdown{}, &ResultInfo{}, err
+			return append([]ZoneLockdown{}, zoneLockdowns...), &ResultInfo{}, err
 		}
 
 		err = json.Unmarshal(res, &zResponse)
 		if err != nil {
-			return []ZoneLockdown{}, &ResultInfo{}, fmt.Errorf("failed to unmarshal filters JSON data: %w", err)
+			return append([]ZoneLockdown{}, zoneLockdowns...), &ResultInfo{}, fmt.Errorf("failed to unmarshal filters JSON data: %w", err)
 		}
 
 		zoneLockdowns = append(zoneLockdowns, zResponse.Result...)
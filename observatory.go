@@ -192,12 +192,12 @@ func (api *API) ListObservatoryPageTests(ctx context.Context, rc *ResourceContai
 		uri := fmt.Sprintf("/zones/%s/speed_api/pages/%s/tests?%s", rc.Identifier, url.PathEscape(params.URL), v.Encode())
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return nil, nil, err
+			return tests, nil, err
 		}
 		var r ObservatoryPageTestsResponse
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return nil, nil, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return tests, nil, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		tests = append(tests, r.Result...)
 		lastResultInfo = r.ResultInfo.Next()
@@ -1,6 +1,8 @@
 package cloudflare
 
 import (
+	"crypto/tls"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -18,6 +20,51 @@ func HTTPClient(client *http.Client) Option {
 	}
 }
 
+// UsingMinTLSVersion sets the minimum TLS version, such as tls.VersionTLS13,
+// used to connect to the API. It only applies to the default HTTP client and
+// is ignored when a client is provided with HTTPClient. Defaults to
+// tls.VersionTLS12.
+func UsingMinTLSVersion(version uint16) Option {
+	return func(api *API) error {
+		switch version {
+		case tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13:
+			api.minTLSVersion = version
+			return nil
+		default:
+			return fmt.Errorf("unknown TLS version %#04x", version)
+		}
+	}
+}
+
+// UsingDialTimeout sets how long to wait for a connection to the API to be
+// established, separately from the time taken to respond. A timeout of zero
+// leaves it to the operating system. It only applies to the default HTTP client and is
+// ignored when a client is provided with HTTPClient. Defaults to 30 seconds.
+func UsingDialTimeout(timeout time.Duration) Option {
+	return func(api *API) error {
+		if timeout < 0 {
+			return fmt.Errorf("dial timeout must not be negative: %s", timeout)
+		}
+		api.dialTimeout = timeout
+		return nil
+	}
+}
+
+// UsingResponseHeaderTimeout sets how long to wait for the response headers
+// once a request has been sent, separately from the time taken to connect. It
+// does not include reading the response body. A timeout of zero waits
+// indefinitely. It only applies to the default HTTP client and is ignored when
+// a client is provided with HTTPClient. Defaults to 60 seconds.
+func UsingResponseHeaderTimeout(timeout time.Duration) Option {
+	return func(api *API) error {
+		if timeout < 0 {
+			return fmt.Errorf("response header timeout must not be negative: %s", timeout)
+		}
+		api.headerTimeout = timeout
+		return nil
+	}
+}
+
 // Headers allows you to set custom HTTP headers when making API calls (e.g. for
 // satisfying HTTP proxies, or for debugging).
 func Headers(headers http.Header) Option {
@@ -27,6 +74,30 @@ func Headers(headers http.Header) Option {
 	}
 }
 
+// UsingDefaultHeaders adds headers sent with every request, such as a static
+// tag identifying the caller. Headers set with Headers and by a request
+// itself take precedence. The credential headers (Authorization, X-Auth-Key,
+// X-Auth-Email and X-Auth-User-Service-Key) are reserved and rejected.
+func UsingDefaultHeaders(headers map[string]string) Option {
+	return func(api *API) error {
+		for name := range headers {
+			for _, reserved := range reservedHeaders {
+				if http.CanonicalHeaderKey(name) == reserved {
+					return fmt.Errorf("header %q is reserved", name)
+				}
+			}
+		}
+
+		if api.defaultHeaders == nil {
+			api.defaultHeaders = make(http.Header)
+		}
+		for name, value := range headers {
+			api.defaultHeaders.Set(name, value)
+		}
+		return nil
+	}
+}
+
 // UsingRateLimit applies a non-default rate limit to client API requests
 // If not specified the default of 4rps will be applied.
 func UsingRateLimit(rps float64) Option {
@@ -40,6 +111,18 @@ func UsingRateLimit(rps float64) Option {
 	}
 }
 
+// UsingSharedRateLimiter makes every request, including retries, also wait on
+// limiter in addition to the rate limit of the client. Passing the same
+// limiter to several clients keeps their combined request rate within it, for
+// example when they use the same account. Waiting stops when the request
+// context is done. By default there is no shared limiter.
+func UsingSharedRateLimiter(limiter *rate.Limiter) Option {
+	return func(api *API) error {
+		api.sharedLimiter = limiter
+		return nil
+	}
+}
+
 // UsingRetryPolicy applies a non-default number of retries and min/max retry delays
 // This will be used when the client exponentially backs off after errored requests.
 func UsingRetryPolicy(maxRetries int, minRetryDelaySecs int, maxRetryDelaySecs int) Option {
@@ -63,6 +146,142 @@ func UsingLogger(logger Logger) Option {
 	}
 }
 
+// UsingMaxQueryLength rejects requests whose query string is longer than
+// maxLength characters with ErrQueryTooLong rather than sending them and
+// receiving a 414 response. By default the length is not limited.
+func UsingMaxQueryLength(maxLength int) Option {
+	return func(api *API) error {
+		api.maxQueryLength = maxLength
+		return nil
+	}
+}
+
+// UsingStreamAuditHook sets a function called after every request which
+// creates, updates or deletes a Stream resource, whether it succeeded or not.
+func UsingStreamAuditHook(hook func(StreamAuditEvent)) Option {
+	return func(api *API) error {
+		api.streamAuditHook = hook
+		return nil
+	}
+}
+
+// UsingTenantContextKey sets the context key holding the tenant ID of a
+// request. When the request context has a value for the key, it is included
+// in log lines and in StreamAuditEvent.TenantID. By default no tenant ID is
+// extracted.
+func UsingTenantContextKey(key interface{}) Option {
+	return func(api *API) error {
+		api.tenantContextKey = key
+		return nil
+	}
+}
+
+// UsingCorrelationIDHeader sends the correlation ID set on the request context
+// with WithCorrelationID in the given header, defaulting to
+// "X-Correlation-Id" when header is empty. When generate is true, requests
+// whose context has no correlation ID get a random one, which is kept across
+// retries of the request and included in its log lines.
+func UsingCorrelationIDHeader(header string, generate bool) Option {
+	return func(api *API) error {
+		if header == "" {
+			header = "X-Correlation-Id"
+		}
+		api.correlationIDHeader = header
+		api.generateCorrelationID = generate
+		return nil
+	}
+}
+
+// UsingIdempotentStreamLiveInputDeletes makes DeleteStreamLiveInput treat a
+// not found response to a retried delete as success, as the earlier attempt
+// which failed with a server error may have deleted the live input. A not
+// found response to the first attempt is still returned as an error.
+func UsingIdempotentStreamLiveInputDeletes(enabled bool) Option {
+	return func(api *API) error {
+		api.idempotentStreamLiveInputDeletes = enabled
+		return nil
+	}
+}
+
+// UsingStrictStreamAccessSettings reports creating or updating Stream videos,
+// clips and live input recordings with allowed origins but without requiring
+// signed URLs as an error. The request is still sent, and when it succeeds
+// the result is returned together with ErrAllowedOriginsWithoutSignedURLs,
+// which callers can check with errors.Is. Allowed origins alone don't
+// restrict playback paths which send no Origin or Referer header. By default
+// a warning is logged instead.
+func UsingStrictStreamAccessSettings(strict bool) Option {
+	return func(api *API) error {
+		api.strictStreamAccessSettings = strict
+		return nil
+	}
+}
+
+// UsingEmptyResultErrors returns ErrEmptyResult from StreamGetVideo and
+// GetStreamLiveInput when the API reports success but the result has no
+// UID, rather than returning the empty result. By default the empty result is
+// returned without an error.
+func UsingEmptyResultErrors(enabled bool) Option {
+	return func(api *API) error {
+		api.emptyResultErrors = enabled
+		return nil
+	}
+}
+
+// UsingMaxUploadBufferSize sets the size in bytes above which file uploads are
+// streamed from disk instead of being buffered in memory. Buffered uploads are
+// retried like any other request, but streamed uploads are sent only once as
+// their body cannot be replayed, so failures must be retried by the caller. By
+// default files of up to 64 MiB are buffered.
+func UsingMaxUploadBufferSize(size int64) Option {
+	return func(api *API) error {
+		if size < 0 {
+			return fmt.Errorf("max upload buffer size must not be negative: %d", size)
+		}
+		api.maxUploadBufferSize = size
+		return nil
+	}
+}
+
+// UsingStreamSignedURLExpiry sets the lifetime of tokens created with
+// StreamCreateSignedURL when the parameters don't set EXP. The expiry is
+// computed from the time the token is requested. By default EXP is left unset
+// and the API applies its own default of one hour.
+func UsingStreamSignedURLExpiry(expiry time.Duration) Option {
+	return func(api *API) error {
+		api.streamSignedURLExpiry = expiry
+		return nil
+	}
+}
+
+// UsingStreamSignedURLMaxExpiry caps the lifetime of tokens created with
+// StreamCreateSignedURL and GenerateStreamSignedTokens. Requests with an EXP
+// more than maxExpiry from now fail with ErrStreamSignedURLExpiryTooLong.
+// Tokens without an EXP are given maxExpiry when the expiry set with
+// UsingStreamSignedURLExpiry, or the default of one hour, is longer. By
+// default the lifetime is not capped.
+func UsingStreamSignedURLMaxExpiry(maxExpiry time.Duration) Option {
+	return func(api *API) error {
+		api.streamSignedURLMaxExpiry = maxExpiry
+		return nil
+	}
+}
+
+// UsingStreamLiveInputDefaults sets parameters applied to every live input
+// created with CreateStreamLiveInput. Values explicitly set on the create
+// parameters take precedence over these defaults, except for the boolean
+// recording settings RequireSignedURLs and HideLiveViewerCount: false can't
+// be told apart from unset, so a default of true can't be turned off for a
+// single live input. Leave those unset in the defaults when some live inputs
+// need them off. PreferLowLatency and DisableRecording are not taken from
+// the defaults.
+func UsingStreamLiveInputDefaults(defaults CreateStreamLiveInputParameters) Option {
+	return func(api *API) error {
+		api.streamLiveInputDefaults = &defaults
+		return nil
+	}
+}
+
 // UserAgent can be set if you want to send a software name and version for HTTP access logs.
 // It is recommended to set it in order to help future Customer Support diagnostics
 // and prevent collateral damage by sharing generic User-Agent string with abusive users.
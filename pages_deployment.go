@@ -153,11 +153,11 @@ func (api *API) ListPagesDeployments(ctx context.Context, rc *ResourceContainer,
 		uri := buildURI(fmt.Sprintf("/accounts/%s/pages/projects/%s/deployments", rc.Identifier, params.ProjectName), params)
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []PagesProjectDeployment{}, &ResultInfo{}, err
+			return append([]PagesProjectDeployment{}, deployments...), &ResultInfo{}, err
 		}
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return []PagesProjectDeployment{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]PagesProjectDeployment{}, deployments...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		deployments = append(deployments, r.Result...)
 		params.ResultInfo = r.ResultInfo.Next()
@@ -129,12 +129,12 @@ func (api *API) ListQueues(ctx context.Context, rc *ResourceContainer, params Li
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []Queue{}, &ResultInfo{}, err
+			return append([]Queue{}, queues...), &ResultInfo{}, err
 		}
 
 		err = json.Unmarshal(res, &qResponse)
 		if err != nil {
-			return []Queue{}, &ResultInfo{}, fmt.Errorf("failed to unmarshal filters JSON data: %w", err)
+			return append([]Queue{}, queues...), &ResultInfo{}, fmt.Errorf("failed to unmarshal filters JSON data: %w", err)
 		}
 
 		queues = append(queues, qResponse.Result...)
@@ -277,12 +277,12 @@ func (api *API) ListQueueConsumers(ctx context.Context, rc *ResourceContainer, p
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []QueueConsumer{}, &ResultInfo{}, err
+			return append([]QueueConsumer{}, queuesConsumers...), &ResultInfo{}, err
 		}
 
 		err = json.Unmarshal(res, &qResponse)
 		if err != nil {
-			return []QueueConsumer{}, &ResultInfo{}, fmt.Errorf("failed to unmarshal filters JSON data: %w", err)
+			return append([]QueueConsumer{}, queuesConsumers...), &ResultInfo{}, fmt.Errorf("failed to unmarshal filters JSON data: %w", err)
 		}
 
 		queuesConsumers = append(queuesConsumers, qResponse.Result...)
@@ -1,6 +1,9 @@
 package cloudflare
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+)
 
 // RouteLevel holds the "level" where the resource resides. Commonly used in
 // routing configurations or builders.
@@ -28,6 +31,10 @@ const (
 	UserType    ResourceType = user
 )
 
+// accountIDPattern matches the shape of an account ID. Zone IDs share the
+// same shape so they cannot be told apart from account IDs.
+var accountIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+
 // ResourceContainer defines an API resource you wish to target. Should not be
 // used directly, use `UserIdentifier`, `ZoneIdentifier` and `AccountIdentifier`
 // instead.
@@ -112,3 +119,17 @@ func AccountIdentifier(id string) *ResourceContainer {
 		Type:       AccountType,
 	}
 }
+
+// validateAccountID returns ErrMissingAccountID when id is empty and
+// ErrInvalidAccountID when it is not a 32 character hex string, which catches
+// zone names, emails and UUIDs passed by mistake before they result in a
+// confusing 404 from the API.
+func validateAccountID(id string) error {
+	if id == "" {
+		return ErrMissingAccountID
+	}
+	if !accountIDPattern.MatchString(id) {
+		return fmt.Errorf("%w %q: expected a 32 character hex string, not a zone name or other identifier", ErrInvalidAccountID, id)
+	}
+	return nil
+}
@@ -64,3 +64,24 @@ func TestResourcURLFragment(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAccountID(t *testing.T) {
+	assert.NoError(t, validateAccountID(testAccountID))
+	assert.NoError(t, validateAccountID("01A7362D577A6C3019A474FD6F485823"))
+	// Zone IDs have the same shape as account IDs so they are not flagged.
+	assert.NoError(t, validateAccountID(testZoneID))
+
+	assert.Equal(t, ErrMissingAccountID, validateAccountID(""))
+
+	for _, id := range []string{
+		"example.com",
+		"user@example.com",
+		testTunnelID,
+		testAccountID[:31],
+		testAccountID + "0",
+		"01a7362d577a6c3019a474fd6f48582g",
+	} {
+		err := validateAccountID(id)
+		assert.ErrorIs(t, err, ErrInvalidAccountID, id)
+	}
+}
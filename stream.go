@@ -9,7 +9,9 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -22,6 +24,10 @@ var (
 	ErrMissingUploadURL = errors.New("required url missing")
 	// ErrMissingMaxDuration is for when MaxDuration is required but missing.
 	ErrMissingMaxDuration = errors.New("required max duration missing")
+	// ErrAllowedOriginsWithoutSignedURLs is for when allowed origins are set on a video which does not require signed URLs.
+	ErrAllowedOriginsWithoutSignedURLs = errors.New("allowed origins are set without requiring signed URLs")
+	// ErrInvalidStreamListRange is for when After is not before Before when listing videos.
+	ErrInvalidStreamListRange = errors.New("list range start must be before its end")
 	// ErrMissingVideoID is for when VideoID is required but missing.
 	ErrMissingVideoID = errors.New("required video id missing")
 	// ErrMissingFilePath is for when FilePath is required but missing.
@@ -36,6 +42,20 @@ var (
 	ErrMissingUploadLength = errors.New("required upload length missing")
 	// ErrInvalidStatusCode is for when the status code is invalid.
 	ErrInvalidStatusCode = errors.New("invalid status code")
+	// ErrStreamVideoNotClip is for when a video is expected to be a clip but was not clipped from another video.
+	ErrStreamVideoNotClip = errors.New("video is not a clip")
+	// ErrInvalidStreamMeta is for when a meta value is not a string, number or boolean.
+	ErrInvalidStreamMeta = errors.New("meta values must be strings, numbers or booleans")
+	// ErrStreamVideoProcessingFailed is for when a video could not be processed.
+	ErrStreamVideoProcessingFailed = errors.New("stream video processing failed")
+	// ErrStreamVideoNotReady is for when a video is still processing and can't be played yet.
+	ErrStreamVideoNotReady = errors.New("stream video is not ready to stream")
+	// ErrStreamSignedURLExpiryTooLong is for when a signed URL would expire after the maximum set with UsingStreamSignedURLMaxExpiry.
+	ErrStreamSignedURLExpiryTooLong = errors.New("signed URL expiry exceeds the maximum allowed")
+	// ErrInvalidStreamAccessRule is for when a signed URL access rule is malformed.
+	ErrInvalidStreamAccessRule = errors.New("invalid access rule")
+	// ErrInvalidStreamAllowedOrigin is for when an allowed origin is not a hostname.
+	ErrInvalidStreamAllowedOrigin = errors.New("allowed origins must be hostnames, optionally prefixed with \"*.\"")
 )
 
 type TusProtocolVersion string
@@ -57,6 +77,7 @@ type StreamVideo struct {
 	Playback              StreamVideoPlayback      `json:"playback,omitempty"`
 	Preview               string                   `json:"preview,omitempty"`
 	ReadyToStream         bool                     `json:"readyToStream,omitempty"`
+	ReadyToStreamAt       *time.Time               `json:"readyToStreamAt,omitempty"`
 	RequireSignedURLs     bool                     `json:"requireSignedURLs,omitempty"`
 	Size                  int                      `json:"size,omitempty"`
 	Status                StreamVideoStatus        `json:"status,omitempty"`
@@ -68,15 +89,84 @@ type StreamVideo struct {
 	Uploaded              *time.Time               `json:"uploaded,omitempty"`
 	ScheduledDeletion     *time.Time               `json:"scheduledDeletion,omitempty"`
 	Watermark             StreamVideoWatermark     `json:"watermark,omitempty"`
+	DownloadedFrom        string                   `json:"downloadedFrom,omitempty"`
+	ClippedFromVideoUID   string                   `json:"clippedFromVideoUID,omitempty"`
 	NFT                   StreamVideoNFTParameters `json:"nft,omitempty"`
 }
 
+// UnmarshalJSON decodes a video, accepting timestamps in the layouts of
+// streamTimeLayouts. Timestamps in any other layout are left nil rather than
+// failing to decode the whole video.
+func (v *StreamVideo) UnmarshalJSON(data []byte) error {
+	type Alias StreamVideo
+
+	aux := &struct {
+		Created           string `json:"created,omitempty"`
+		Modified          string `json:"modified,omitempty"`
+		UploadExpiry      string `json:"uploadExpiry,omitempty"`
+		ReadyToStreamAt   string `json:"readyToStreamAt,omitempty"`
+		Uploaded          string `json:"uploaded,omitempty"`
+		ScheduledDeletion string `json:"scheduledDeletion,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(v),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	v.Created = parseStreamTime(aux.Created)
+	v.Modified = parseStreamTime(aux.Modified)
+	v.UploadExpiry = parseStreamTime(aux.UploadExpiry)
+	v.ReadyToStreamAt = parseStreamTime(aux.ReadyToStreamAt)
+	v.Uploaded = parseStreamTime(aux.Uploaded)
+	v.ScheduledDeletion = parseStreamTime(aux.ScheduledDeletion)
+	return nil
+}
+
+// IsReady reports whether the video has finished processing and can be
+// played.
+func (v StreamVideo) IsReady() bool {
+	return v.ReadyToStream || v.Status.IsReady()
+}
+
+// PlaybackURLs returns the HLS and DASH manifest URLs of the video.
+// ErrStreamVideoNotReady is returned while the video is still processing, as
+// the manifests are not playable yet, unless force is set.
+func (v StreamVideo) PlaybackURLs(force bool) (StreamVideoPlayback, error) {
+	if !force && !v.IsReady() {
+		return StreamVideoPlayback{}, ErrStreamVideoNotReady
+	}
+	return v.Playback, nil
+}
+
+// IsClip reports whether the video was clipped from another video.
+func (v StreamVideo) IsClip() bool {
+	return v.ClippedFromVideoUID != ""
+}
+
+// SourceVideoUID returns the UID of the video this video was clipped from,
+// or an empty string when the video is not a clip.
+func (v StreamVideo) SourceVideoUID() string {
+	return v.ClippedFromVideoUID
+}
+
 // StreamVideoInput represents the video input values of a stream video.
 type StreamVideoInput struct {
 	Height int `json:"height,omitempty"`
 	Width  int `json:"width,omitempty"`
 }
 
+// Resolution returns the resolution of the input video such as "1920x1080",
+// or an empty string when it is not known yet, which the API reports as -1.
+func (i StreamVideoInput) Resolution() string {
+	if i.Width <= 0 || i.Height <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dx%d", i.Width, i.Height)
+}
+
 // StreamVideoPlayback represents the playback URLs for a video.
 type StreamVideoPlayback struct {
 	HLS  string `json:"hls,omitempty"`
@@ -91,6 +181,33 @@ type StreamVideoStatus struct {
 	ErrorReasonText string `json:"errorReasonText,omitempty"`
 }
 
+// IsReady reports whether the video has finished processing.
+func (s StreamVideoStatus) IsReady() bool {
+	return s.State == "ready"
+}
+
+// IsErrored reports whether the video failed to upload or process.
+func (s StreamVideoStatus) IsErrored() bool {
+	return s.State == "error"
+}
+
+// ErrorReason returns the reason the video failed as "code: text", or an
+// empty string when it has not failed.
+func (s StreamVideoStatus) ErrorReason() string {
+	if !s.IsErrored() {
+		return ""
+	}
+
+	switch {
+	case s.ErrorReasonCode != "" && s.ErrorReasonText != "":
+		return s.ErrorReasonCode + ": " + s.ErrorReasonText
+	case s.ErrorReasonCode != "":
+		return s.ErrorReasonCode
+	default:
+		return s.ErrorReasonText
+	}
+}
+
 // StreamVideoWatermark represents a watermark for a stream video.
 type StreamVideoWatermark struct {
 	UID            string     `json:"uid,omitempty"`
@@ -142,6 +259,35 @@ type StreamCreateVideoParameters struct {
 	ScheduledDeletion     *time.Time              `json:"scheduledDeletion,omitempty"`
 }
 
+// UpdateStreamVideoParameters are the parameters used when updating the
+// details of a video. Unset fields are left unchanged.
+type UpdateStreamVideoParameters struct {
+	VideoID               string                 `json:"-"`
+	Creator               string                 `json:"creator,omitempty"`
+	Meta                  map[string]interface{} `json:"meta,omitempty"`
+	AllowedOrigins        []string               `json:"allowedOrigins,omitempty"`
+	RequireSignedURLs     *bool                  `json:"requireSignedURLs,omitempty"`
+	ThumbnailTimestampPct float64                `json:"thumbnailTimestampPct,omitempty"`
+	ScheduledDeletion     *time.Time             `json:"scheduledDeletion,omitempty"`
+}
+
+// streamVideoUpdate returns update parameters which send back the creator,
+// meta, allowed origins, signed URL requirement, thumbnail timestamp and
+// scheduled deletion of video, as the API resets those left out of an update.
+// Callers change the fields they mean to update on the result.
+func streamVideoUpdate(video StreamVideo) UpdateStreamVideoParameters {
+	requireSignedURLs := video.RequireSignedURLs
+	return UpdateStreamVideoParameters{
+		VideoID:               video.UID,
+		Creator:               video.Creator,
+		Meta:                  video.Meta,
+		AllowedOrigins:        video.AllowedOrigins,
+		RequireSignedURLs:     &requireSignedURLs,
+		ThumbnailTimestampPct: video.ThumbnailTimestampPct,
+		ScheduledDeletion:     video.ScheduledDeletion,
+	}
+}
+
 // UploadVideoURLWatermark represents UID of an existing watermark.
 type UploadVideoURLWatermark struct {
 	UID string `json:"uid,omitempty"`
@@ -171,8 +317,11 @@ type StreamUploadFileParameters struct {
 
 // StreamListParameters represents parameters used when listing stream videos.
 type StreamListParameters struct {
-	AccountID     string
-	VideoID       string
+	AccountID string
+	VideoID   string
+
+	// After and Before only list videos created after and before the given
+	// times. When both are set, After must be before Before.
 	After         *time.Time `url:"after,omitempty"`
 	Before        *time.Time `url:"before,omitempty"`
 	Creator       string     `url:"creator,omitempty"`
@@ -214,6 +363,11 @@ type TUSUploadMetadata struct {
 	ThumbnailTimestampPct float64    `json:"thumbnailtimestamppct,omitempty"`
 	ScheduledDeletion     *time.Time `json:"scheduledDeletion,omitempty"`
 	Watermark             string     `json:"watermark,omitempty"`
+
+	// Meta holds additional metadata pairs. Keys must be non-empty, printable
+	// ASCII without spaces or commas and values are base64 encoded. An empty
+	// value sends the key on its own.
+	Meta map[string]string `json:"-"`
 }
 
 func (t TUSUploadMetadata) ToTUSCsv() (string, error) {
@@ -237,6 +391,29 @@ func (t TUSUploadMetadata) ToTUSCsv() (string, error) {
 		metadataValues = append(metadataValues, fmt.Sprintf("%s %s", "watermark", base64.StdEncoding.EncodeToString([]byte(t.Watermark))))
 	}
 
+	keys := make([]string, 0, len(t.Meta))
+	for k := range t.Meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if !validTUSMetadataKey(k) {
+			return "", fmt.Errorf("invalid metadata key %q", k)
+		}
+		for _, v := range metadataValues {
+			if v == k || strings.HasPrefix(v, k+" ") {
+				return "", fmt.Errorf("duplicate metadata key %q", k)
+			}
+		}
+
+		if t.Meta[k] == "" {
+			metadataValues = append(metadataValues, k)
+		} else {
+			metadataValues = append(metadataValues, fmt.Sprintf("%s %s", k, base64.StdEncoding.EncodeToString([]byte(t.Meta[k]))))
+		}
+	}
+
 	if len(metadataValues) > 0 {
 		return strings.Join(metadataValues, ","), nil
 	}
@@ -244,6 +421,20 @@ func (t TUSUploadMetadata) ToTUSCsv() (string, error) {
 	return "", nil
 }
 
+// validTUSMetadataKey reports whether k can be used as a key in the TUS
+// Upload-Metadata header.
+func validTUSMetadataKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for _, r := range k {
+		if r <= ' ' || r > '~' || r == ',' {
+			return false
+		}
+	}
+	return true
+}
+
 // StreamVideoResponse represents an API response of a stream video.
 type StreamVideoResponse struct {
 	Response
@@ -280,70 +471,136 @@ type StreamAccessRule struct {
 	IP      []string `json:"ip,omitempty"`
 }
 
+// validate checks that the rule uses a known type and action and that it
+// carries the country or IP list its type requires.
+func (r StreamAccessRule) validate() error {
+	switch r.Action {
+	case "allow", "block":
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidStreamAccessRule, r.Action)
+	}
+
+	switch r.Type {
+	case "any":
+		if len(r.Country) > 0 || len(r.IP) > 0 {
+			return fmt.Errorf("%w: type %q does not take a country or ip list", ErrInvalidStreamAccessRule, r.Type)
+		}
+	case "ip.geoip.country":
+		if len(r.Country) == 0 {
+			return fmt.Errorf("%w: type %q requires a country list", ErrInvalidStreamAccessRule, r.Type)
+		}
+		for _, country := range r.Country {
+			if country == "" {
+				return fmt.Errorf("%w: empty country", ErrInvalidStreamAccessRule)
+			}
+		}
+	case "ip.src":
+		if len(r.IP) == 0 {
+			return fmt.Errorf("%w: type %q requires an ip list", ErrInvalidStreamAccessRule, r.Type)
+		}
+		for _, ip := range r.IP {
+			if ip == "" {
+				return fmt.Errorf("%w: empty ip", ErrInvalidStreamAccessRule)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidStreamAccessRule, r.Type)
+	}
+	return nil
+}
+
 // StreamUploadFromURL send a video URL to it will be downloaded and made available on Stream.
 //
 // API Reference: https://api.cloudflare.com/#stream-videos-upload-a-video-from-a-url
 func (api *API) StreamUploadFromURL(ctx context.Context, params StreamUploadFromURLParameters) (StreamVideo, error) {
-	if params.AccountID == "" {
-		return StreamVideo{}, ErrMissingAccountID
+	if err := validateAccountID(params.AccountID); err != nil {
+		return StreamVideo{}, err
 	}
 
 	if params.URL == "" {
 		return StreamVideo{}, ErrMissingUploadURL
 	}
 
-	uri := fmt.Sprintf("/accounts/%s/stream/copy", params.AccountID)
+	meta, err := normalizeStreamMeta(params.Meta)
+	if err != nil {
+		return StreamVideo{}, err
+	}
+	params.Meta = meta
+
+	warning := api.checkStreamAllowedOrigins("StreamUploadFromURL", params.AllowedOrigins, params.RequireSignedURLs)
+
+	uri := streamBasePath(params.AccountID) + "/copy"
 
 	res, err := api.makeRequestContext(ctx, http.MethodPost, uri, params)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "StreamUploadFromURL", AccountID: params.AccountID, VideoID: params.VideoID}, err)
 	if err != nil {
-		return StreamVideo{}, err
+		return StreamVideo{}, wrapStreamTransportError("StreamUploadFromURL", uri, err)
 	}
 
 	var streamVideoResponse StreamVideoResponse
 	if err := json.Unmarshal(res, &streamVideoResponse); err != nil {
 		return StreamVideo{}, err
 	}
-	return streamVideoResponse.Result, nil
+	return streamVideoResponse.Result, warning
 }
 
 // StreamUploadVideoFile uploads a video from a path to the file.
 //
+// Files up to the size set with UsingMaxUploadBufferSize are buffered in memory
+// so that the upload can be retried. Larger files are streamed from disk and
+// are not retried, so a failed upload has to be restarted by the caller.
+//
 // API Reference: https://api.cloudflare.com/#stream-videos-upload-a-video-using-a-single-http-request
 func (api *API) StreamUploadVideoFile(ctx context.Context, params StreamUploadFileParameters) (StreamVideo, error) {
-	if params.AccountID == "" {
-		return StreamVideo{}, ErrMissingAccountID
+	if err := validateAccountID(params.AccountID); err != nil {
+		return StreamVideo{}, err
 	}
 
 	if params.FilePath == "" {
 		return StreamVideo{}, ErrMissingFilePath
 	}
 
-	uri := fmt.Sprintf("/accounts/%s/stream", params.AccountID)
+	uri := streamBasePath(params.AccountID)
 
-	// Create new multipart writer
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	formFile, err := writer.CreateFormFile("file", params.FilePath)
-	if err != nil {
-		return StreamVideo{}, err
-	}
 	file, err := os.Open(params.FilePath)
 	if err != nil {
 		return StreamVideo{}, err
 	}
-	if _, err := io.Copy(formFile, file); err != nil {
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
 		return StreamVideo{}, err
 	}
-	if err := writer.Close(); err != nil {
-		return StreamVideo{}, err
+
+	var body interface{}
+	var writer *multipart.Writer
+	if info.Size() <= api.maxUploadBufferSize {
+		buf := &bytes.Buffer{}
+		writer = multipart.NewWriter(buf)
+		if err := writeStreamUploadForm(writer, params.FilePath, file); err != nil {
+			return StreamVideo{}, err
+		}
+		body = buf
+	} else {
+		// Closing the reader once the request is done stops the writer if
+		// the request failed before the whole file was sent.
+		pr, pw := io.Pipe()
+		defer pr.Close()
+		writer = multipart.NewWriter(pw)
+		go func() {
+			pw.CloseWithError(writeStreamUploadForm(writer, params.FilePath, file))
+		}()
+		body = streamedBody{pr}
 	}
 
 	res, err := api.makeRequestContextWithHeaders(ctx, http.MethodPost, uri, body, http.Header{
 		"Accept":       []string{"application/json"},
 		"Content-Type": []string{writer.FormDataContentType()},
 	})
+	api.auditStream(ctx, StreamAuditEvent{Operation: "StreamUploadVideoFile", AccountID: params.AccountID, VideoID: params.VideoID}, err)
 	if err != nil {
-		return StreamVideo{}, err
+		return StreamVideo{}, wrapStreamTransportError("StreamUploadVideoFile", uri, err)
 	}
 
 	var streamVideoResponse StreamVideoResponse
@@ -353,45 +610,70 @@ func (api *API) StreamUploadVideoFile(ctx context.Context, params StreamUploadFi
 	return streamVideoResponse.Result, nil
 }
 
+// writeStreamUploadForm writes file as the multipart form of a video upload.
+func writeStreamUploadForm(writer *multipart.Writer, filePath string, file io.Reader) error {
+	formFile, err := writer.CreateFormFile("file", filePath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(formFile, file); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
 // StreamCreateVideoDirectURL creates a video and returns an authenticated URL.
 //
 // API Reference: https://api.cloudflare.com/#stream-videos-create-a-video-and-get-authenticated-direct-upload-url
 func (api *API) StreamCreateVideoDirectURL(ctx context.Context, params StreamCreateVideoParameters) (StreamVideoCreate, error) {
-	if params.AccountID == "" {
-		return StreamVideoCreate{}, ErrMissingAccountID
+	if err := validateAccountID(params.AccountID); err != nil {
+		return StreamVideoCreate{}, err
 	}
 
 	if params.MaxDurationSeconds == 0 {
 		return StreamVideoCreate{}, ErrMissingMaxDuration
 	}
 
-	uri := fmt.Sprintf("/accounts/%s/stream/direct_upload", params.AccountID)
+	meta, err := normalizeStreamMeta(params.Meta)
+	if err != nil {
+		return StreamVideoCreate{}, err
+	}
+	params.Meta = meta
+
+	warning := api.checkStreamAllowedOrigins("StreamCreateVideoDirectURL", params.AllowedOrigins, params.RequireSignedURLs)
+
+	uri := streamBasePath(params.AccountID) + "/direct_upload"
 
 	res, err := api.makeRequestContext(ctx, http.MethodPost, uri, params)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "StreamCreateVideoDirectURL", AccountID: params.AccountID}, err)
 	if err != nil {
-		return StreamVideoCreate{}, err
+		return StreamVideoCreate{}, wrapStreamTransportError("StreamCreateVideoDirectURL", uri, err)
 	}
 
 	var streamVideoCreateResponse StreamVideoCreateResponse
 	if err := json.Unmarshal(res, &streamVideoCreateResponse); err != nil {
 		return StreamVideoCreate{}, err
 	}
-	return streamVideoCreateResponse.Result, nil
+	return streamVideoCreateResponse.Result, warning
 }
 
 // StreamListVideos list videos currently in stream.
 //
 // API reference: https://api.cloudflare.com/#stream-videos-list-videos
 func (api *API) StreamListVideos(ctx context.Context, params StreamListParameters) ([]StreamVideo, error) {
-	if params.AccountID == "" {
-		return []StreamVideo{}, ErrMissingAccountID
+	if err := validateAccountID(params.AccountID); err != nil {
+		return []StreamVideo{}, err
 	}
 
-	uri := buildURI(fmt.Sprintf("/accounts/%s/stream", params.AccountID), params)
+	if params.After != nil && params.Before != nil && !params.After.Before(*params.Before) {
+		return []StreamVideo{}, fmt.Errorf("%w: %s is not before %s", ErrInvalidStreamListRange, params.After.Format(time.RFC3339), params.Before.Format(time.RFC3339))
+	}
+
+	uri := buildURI(streamBasePath(params.AccountID), params)
 
 	res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
-		return []StreamVideo{}, err
+		return []StreamVideo{}, wrapStreamTransportError("StreamListVideos", uri, err)
 	}
 
 	var streamListResponse StreamListResponse
@@ -430,16 +712,17 @@ func (api *API) StreamInitiateTUSVideoUpload(ctx context.Context, rc *ResourceCo
 
 	metadataTusCsv, err := params.Metadata.ToTUSCsv()
 	if err != nil {
-		return StreamInitiateTUSUploadResponse{}, ErrMarshallingTUSMetadata
+		return StreamInitiateTUSUploadResponse{}, fmt.Errorf("%w: %s", ErrMarshallingTUSMetadata, err)
 	}
 	if metadataTusCsv != "" {
 		headers.Set("Upload-Metadata", metadataTusCsv)
 	}
 
-	uri := buildURI(fmt.Sprintf("/accounts/%s/stream", rc.Identifier), params)
+	uri := buildURI(streamBasePath(rc.Identifier), params)
 	res, err := api.makeRequestWithAuthTypeAndHeadersComplete(ctx, http.MethodPost, uri, nil, api.authType, headers)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "StreamInitiateTUSVideoUpload", AccountID: rc.Identifier}, err)
 	if err != nil {
-		return StreamInitiateTUSUploadResponse{}, err
+		return StreamInitiateTUSUploadResponse{}, wrapStreamTransportError("StreamInitiateTUSVideoUpload", uri, err)
 	}
 
 	if res.StatusCode != http.StatusCreated {
@@ -449,49 +732,189 @@ func (api *API) StreamInitiateTUSVideoUpload(ctx context.Context, rc *ResourceCo
 	return StreamInitiateTUSUploadResponse{ResponseHeaders: res.Headers}, nil
 }
 
-// StreamGetVideo gets the details for a specific video.
+// StreamGetVideo gets the details for a specific video. The API can report
+// success with an empty result; see UsingEmptyResultErrors to treat that as an
+// error.
 //
 // API Reference: https://api.cloudflare.com/#stream-videos-video-details
 func (api *API) StreamGetVideo(ctx context.Context, options StreamParameters) (StreamVideo, error) {
-	if options.AccountID == "" {
-		return StreamVideo{}, ErrMissingAccountID
+	if err := validateAccountID(options.AccountID); err != nil {
+		return StreamVideo{}, err
 	}
 
 	if options.VideoID == "" {
 		return StreamVideo{}, ErrMissingVideoID
 	}
 
-	uri := fmt.Sprintf("/accounts/%s/stream/%s", options.AccountID, options.VideoID)
+	uri := fmt.Sprintf("%s/%s", streamBasePath(options.AccountID), options.VideoID)
 
 	res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
-		return StreamVideo{}, err
+		return StreamVideo{}, wrapStreamTransportError("StreamGetVideo", uri, err)
 	}
 	var streamVideoResponse StreamVideoResponse
 	if err := json.Unmarshal(res, &streamVideoResponse); err != nil {
 		return StreamVideo{}, err
 	}
+
+	if api.emptyResultErrors && streamVideoResponse.Result.UID == "" {
+		return StreamVideo{}, fmt.Errorf("%w: StreamGetVideo %s", ErrEmptyResult, options.VideoID)
+	}
 	return streamVideoResponse.Result, nil
 }
 
+// UpdateStreamVideo updates the details of a video.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-videos-update-video-details
+func (api *API) UpdateStreamVideo(ctx context.Context, rc *ResourceContainer, params UpdateStreamVideoParameters) (StreamVideo, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamVideo{}, err
+	}
+
+	if params.VideoID == "" {
+		return StreamVideo{}, ErrMissingVideoID
+	}
+
+	meta, err := normalizeStreamMeta(params.Meta)
+	if err != nil {
+		return StreamVideo{}, err
+	}
+	params.Meta = meta
+
+	// Signed URLs are left unchanged when RequireSignedURLs is nil, so the
+	// current setting is unknown and the origins are not checked.
+	var warning error
+	if params.RequireSignedURLs != nil {
+		warning = api.checkStreamAllowedOrigins("UpdateStreamVideo", params.AllowedOrigins, *params.RequireSignedURLs)
+	}
+
+	uri := fmt.Sprintf("%s/%s", streamBasePath(rc.Identifier), params.VideoID)
+
+	res, err := api.makeRequestContext(ctx, http.MethodPost, uri, params)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "UpdateStreamVideo", AccountID: rc.Identifier, VideoID: params.VideoID}, err)
+	if err != nil {
+		return StreamVideo{}, wrapStreamTransportError("UpdateStreamVideo", uri, err)
+	}
+	var streamVideoResponse StreamVideoResponse
+	if err := json.Unmarshal(res, &streamVideoResponse); err != nil {
+		return StreamVideo{}, err
+	}
+	return streamVideoResponse.Result, warning
+}
+
+// EnsureStreamVideoCreator verifies the creator of a video, such as one
+// uploaded using a direct upload URL, and updates it when it does not match.
+// Like SetStreamVideosCreator, the meta and access settings of the video are
+// sent back with the update so that they are not reset. The video as it is
+// after the check is returned.
+func (api *API) EnsureStreamVideoCreator(ctx context.Context, rc *ResourceContainer, videoID, creator string) (StreamVideo, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamVideo{}, err
+	}
+
+	video, err := api.StreamGetVideo(ctx, StreamParameters{AccountID: rc.Identifier, VideoID: videoID})
+	if err != nil {
+		return StreamVideo{}, err
+	}
+
+	if video.Creator == creator {
+		return video, nil
+	}
+
+	update := streamVideoUpdate(video)
+	update.VideoID = videoID
+	update.Creator = creator
+	return api.UpdateStreamVideo(ctx, rc, update)
+}
+
+// WaitForStreamVideoReady polls a video every interval until it is ready to
+// stream. Only the UID of the video is needed so that polling can be resumed
+// after a restart, for example using the UID of a StreamVideoCreate returned
+// when creating a direct upload. An interval of 0 polls every 2 seconds.
+//
+// ErrStreamVideoProcessingFailed is returned along with the video if it
+// could not be processed.
+func (api *API) WaitForStreamVideoReady(ctx context.Context, rc *ResourceContainer, videoID string, interval time.Duration) (StreamVideo, error) {
+	return api.WaitForStreamVideoReadyWithProgress(ctx, rc, videoID, interval, nil)
+}
+
+// WaitForStreamVideoReadyWithProgress waits like WaitForStreamVideoReady and
+// calls progress, if not nil, with the pctComplete of the video status after
+// every poll, for example to report the download progress of a video
+// uploaded using StreamUploadFromURL. Polls where the percentage is missing or
+// not a number are not reported.
+func (api *API) WaitForStreamVideoReadyWithProgress(ctx context.Context, rc *ResourceContainer, videoID string, interval time.Duration, progress func(pctComplete float64)) (StreamVideo, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamVideo{}, err
+	}
+
+	if interval <= 0 {
+		interval = defaultStreamPollMinInterval
+	}
+
+	for {
+		video, err := api.StreamGetVideo(ctx, StreamParameters{AccountID: rc.Identifier, VideoID: videoID})
+		if err != nil {
+			return StreamVideo{}, err
+		}
+
+		if progress != nil {
+			if pct, err := strconv.ParseFloat(video.Status.PctComplete, 64); err == nil {
+				progress(pct)
+			}
+		}
+
+		if video.Status.IsErrored() {
+			return video, fmt.Errorf("%w: %s", ErrStreamVideoProcessingFailed, video.Status.ErrorReasonText)
+		}
+
+		if video.IsReady() {
+			return video, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return video, err
+		}
+	}
+}
+
+// GetClipSource gets the video a clip was created from.
+// ErrStreamVideoNotClip is returned when the video is not a clip.
+func (api *API) GetClipSource(ctx context.Context, rc *ResourceContainer, videoID string) (StreamVideo, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamVideo{}, err
+	}
+
+	clip, err := api.StreamGetVideo(ctx, StreamParameters{AccountID: rc.Identifier, VideoID: videoID})
+	if err != nil {
+		return StreamVideo{}, err
+	}
+
+	if !clip.IsClip() {
+		return StreamVideo{}, ErrStreamVideoNotClip
+	}
+
+	return api.StreamGetVideo(ctx, StreamParameters{AccountID: rc.Identifier, VideoID: clip.SourceVideoUID()})
+}
+
 // StreamEmbedHTML gets an HTML fragment to embed on a web page.
 //
 // API Reference: https://api.cloudflare.com/#stream-videos-embed-code-html
 func (api *API) StreamEmbedHTML(ctx context.Context, options StreamParameters) (string, error) {
-	if options.AccountID == "" {
-		return "", ErrMissingAccountID
+	if err := validateAccountID(options.AccountID); err != nil {
+		return "", err
 	}
 
 	if options.VideoID == "" {
 		return "", ErrMissingVideoID
 	}
 
-	uri := fmt.Sprintf("/accounts/%s/stream/%s/embed", options.AccountID, options.VideoID)
+	uri := fmt.Sprintf("%s/%s/embed", streamBasePath(options.AccountID), options.VideoID)
 
 	res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 
 	if err != nil {
-		return "", err
+		return "", wrapStreamTransportError("StreamEmbedHTML", uri, err)
 	}
 	return string(res), nil
 }
@@ -500,17 +923,19 @@ func (api *API) StreamEmbedHTML(ctx context.Context, options StreamParameters) (
 //
 // API Reference: https://api.cloudflare.com/#stream-videos-delete-video
 func (api *API) StreamDeleteVideo(ctx context.Context, options StreamParameters) error {
-	if options.AccountID == "" {
-		return ErrMissingAccountID
+	if err := validateAccountID(options.AccountID); err != nil {
+		return err
 	}
 
 	if options.VideoID == "" {
 		return ErrMissingVideoID
 	}
 
-	uri := fmt.Sprintf("/accounts/%s/stream/%s", options.AccountID, options.VideoID)
-	if _, err := api.makeRequestContext(ctx, http.MethodDelete, uri, nil); err != nil {
-		return err
+	uri := fmt.Sprintf("%s/%s", streamBasePath(options.AccountID), options.VideoID)
+	_, err := api.makeRequestContext(ctx, http.MethodDelete, uri, nil)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "StreamDeleteVideo", AccountID: options.AccountID, VideoID: options.VideoID}, err)
+	if err != nil {
+		return wrapStreamTransportError("StreamDeleteVideo", uri, err)
 	}
 	return nil
 }
@@ -519,19 +944,20 @@ func (api *API) StreamDeleteVideo(ctx context.Context, options StreamParameters)
 //
 // API Reference: https://api.cloudflare.com/#stream-videos-associate-video-to-an-nft
 func (api *API) StreamAssociateNFT(ctx context.Context, options StreamVideoNFTParameters) (StreamVideo, error) {
-	if options.AccountID == "" {
-		return StreamVideo{}, ErrMissingAccountID
+	if err := validateAccountID(options.AccountID); err != nil {
+		return StreamVideo{}, err
 	}
 
 	if options.VideoID == "" {
 		return StreamVideo{}, ErrMissingVideoID
 	}
 
-	uri := fmt.Sprintf("/accounts/%s/stream/%s", options.AccountID, options.VideoID)
+	uri := fmt.Sprintf("%s/%s", streamBasePath(options.AccountID), options.VideoID)
 
 	res, err := api.makeRequestContext(ctx, http.MethodPost, uri, options)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "StreamAssociateNFT", AccountID: options.AccountID, VideoID: options.VideoID}, err)
 	if err != nil {
-		return StreamVideo{}, err
+		return StreamVideo{}, wrapStreamTransportError("StreamAssociateNFT", uri, err)
 	}
 	var streamVideoResponse StreamVideoResponse
 	if err := json.Unmarshal(res, &streamVideoResponse); err != nil {
@@ -540,23 +966,38 @@ func (api *API) StreamAssociateNFT(ctx context.Context, options StreamVideoNFTPa
 	return streamVideoResponse.Result, nil
 }
 
-// StreamCreateSignedURL creates a signed URL token for a video.
+// StreamCreateSignedURL creates a signed URL token for a video. When EXP is
+// not set, the expiry configured with UsingStreamSignedURLExpiry is applied,
+// shortened to the maximum set with UsingStreamSignedURLMaxExpiry.
+// ErrStreamSignedURLExpiryTooLong is returned when an explicit EXP is later
+// than that maximum allows.
 //
 // API Reference: https://api.cloudflare.com/#stream-videos-associate-video-to-an-nft
 func (api *API) StreamCreateSignedURL(ctx context.Context, params StreamSignedURLParameters) (string, error) {
-	if params.AccountID == "" {
-		return "", ErrMissingAccountID
+	if err := validateAccountID(params.AccountID); err != nil {
+		return "", err
 	}
 	if params.VideoID == "" {
 		return "", ErrMissingVideoID
 	}
+	for _, rule := range params.AccessRules {
+		if err := rule.validate(); err != nil {
+			return "", err
+		}
+	}
 
-	uri := fmt.Sprintf("/accounts/%s/stream/%s/token", params.AccountID, params.VideoID)
+	exp, err := api.limitStreamSignedURLExpiry(params.EXP, api.streamSignedURLExpiry)
+	if err != nil {
+		return "", err
+	}
+	params.EXP = exp
+
+	uri := fmt.Sprintf("%s/%s/token", streamBasePath(params.AccountID), params.VideoID)
 
 	res, err := api.makeRequestContext(ctx, http.MethodPost, uri, params)
 
 	if err != nil {
-		return "", err
+		return "", wrapStreamTransportError("StreamCreateSignedURL", uri, err)
 	}
 	var streamSignedResponse StreamSignedURLResponse
 	if err := json.Unmarshal(res, &streamSignedResponse); err != nil {
@@ -564,3 +1005,187 @@ func (api *API) StreamCreateSignedURL(ctx context.Context, params StreamSignedUR
 	}
 	return streamSignedResponse.Result.Token, nil
 }
+
+// limitStreamSignedURLExpiry returns the EXP to use for a token. An explicit
+// exp, a Unix timestamp, is checked against the maximum expiry set with
+// UsingStreamSignedURLMaxExpiry. An unset exp is given the default expiry from
+// now, shortened to the maximum; without a default it is left unset for the
+// API default of one hour unless the maximum is shorter.
+func (api *API) limitStreamSignedURLExpiry(exp int, expiry time.Duration) (int, error) {
+	maxExpiry := api.streamSignedURLMaxExpiry
+	if exp != 0 {
+		if maxExpiry <= 0 {
+			return exp, nil
+		}
+		latest := time.Now().Add(maxExpiry)
+		if t := time.Unix(int64(exp), 0); t.After(latest) {
+			return 0, fmt.Errorf("%w: %s is more than %s from now", ErrStreamSignedURLExpiryTooLong, t.UTC().Format(time.RFC3339), maxExpiry)
+		}
+		return exp, nil
+	}
+
+	if expiry <= 0 {
+		if maxExpiry <= 0 || maxExpiry >= defaultStreamSignedTokenExpiry {
+			return 0, nil
+		}
+		expiry = maxExpiry
+	}
+	if maxExpiry > 0 && expiry > maxExpiry {
+		expiry = maxExpiry
+	}
+	return int(time.Now().Add(expiry).Unix()), nil
+}
+
+// StreamAuditEvent describes a mutating Stream operation reported to the hook
+// set with UsingStreamAuditHook. Only the identifiers known when the request
+// is made are set; the UID of a created resource is not included.
+type StreamAuditEvent struct {
+	Operation    string
+	AccountID    string
+	VideoID      string
+	LiveInputID  string
+	OutputID     string
+	SigningKeyID string
+
+	// TenantID is the value stored in the request context under the key set
+	// with UsingTenantContextKey, empty if none.
+	TenantID string
+
+	// Err is the error of the request, nil when it succeeded.
+	Err error
+}
+
+// auditStream reports a mutating Stream operation to the audit hook, if any.
+func (api *API) auditStream(ctx context.Context, event StreamAuditEvent, err error) {
+	if api.streamAuditHook == nil {
+		return
+	}
+	event.TenantID = api.tenantID(ctx)
+	event.Err = err
+	api.streamAuditHook(event)
+}
+
+// streamTimeLayouts are the timestamp layouts accepted when decoding Stream
+// resources. The API documents RFC 3339 timestamps; the others are close
+// variants, without a time zone (assumed UTC) or with a space separator.
+var streamTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// parseStreamTime parses value using the first matching layout of
+// streamTimeLayouts. It returns nil when value is empty or matches none.
+func parseStreamTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	for _, layout := range streamTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// streamBasePath returns the path of the Stream API of an account, with the
+// account ID escaped.
+func streamBasePath(accountID string) string {
+	return "/accounts/" + url.PathEscape(accountID) + "/stream"
+}
+
+// normalizeStreamMeta returns a copy of meta with numbers and booleans
+// converted to strings, as Stream stores meta values as strings. Any other
+// type of value, such as a nested map or slice, is rejected with
+// ErrInvalidStreamMeta.
+func normalizeStreamMeta(meta map[string]interface{}) (map[string]interface{}, error) {
+	if meta == nil {
+		return nil, nil
+	}
+
+	normalized := make(map[string]interface{}, len(meta))
+	for k, v := range meta {
+		switch v := v.(type) {
+		case string:
+			normalized[k] = v
+		case json.Number:
+			normalized[k] = v.String()
+		case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			normalized[k] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("%w: %q is %T", ErrInvalidStreamMeta, k, v)
+		}
+	}
+	return normalized, nil
+}
+
+// checkStreamAllowedOrigins reports allowed origins which are set while signed
+// URLs are not required. Origins are only checked against the Origin and
+// Referer headers, so playback paths which don't send them, such as direct
+// manifest requests, are not restricted. With UsingStrictStreamAccessSettings
+// ErrAllowedOriginsWithoutSignedURLs is returned for the caller to return
+// alongside its result once the request succeeded, otherwise a warning is
+// logged and nil is returned.
+func (api *API) checkStreamAllowedOrigins(op string, origins []string, requireSignedURLs bool) error {
+	if len(origins) == 0 || requireSignedURLs {
+		return nil
+	}
+	if api.strictStreamAccessSettings {
+		return fmt.Errorf("%w: %s", ErrAllowedOriginsWithoutSignedURLs, op)
+	}
+	api.logger.Printf("%s: %s, only requests sending an Origin or Referer header are restricted", op, ErrAllowedOriginsWithoutSignedURLs)
+	return nil
+}
+
+// normalizeStreamAllowedOrigins returns a copy of origins as lowercase
+// hostnames, accepting URLs such as "https://example.com" for convenience and
+// dropping duplicates. Entries other than "*" which are empty or not a
+// hostname, optionally prefixed with "*.", are rejected with
+// ErrInvalidStreamAllowedOrigin.
+func normalizeStreamAllowedOrigins(origins []string) ([]string, error) {
+	if origins == nil {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(origins))
+	seen := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		host := strings.ToLower(strings.TrimSpace(origin))
+		if u, err := url.Parse(host); err == nil && u.Scheme != "" && u.Host != "" {
+			if u.Path != "" && u.Path != "/" {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidStreamAllowedOrigin, origin)
+			}
+			host = u.Hostname()
+		}
+
+		if host == "" || (host != "*" && strings.ContainsAny(strings.TrimPrefix(host, "*."), "*/:?#@ ")) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidStreamAllowedOrigin, origin)
+		}
+
+		if !seen[host] {
+			seen[host] = true
+			normalized = append(normalized, host)
+		}
+	}
+	return normalized, nil
+}
+
+// wrapStreamTransportError adds the name of the Stream operation and the
+// requested URI to errors that occurred before a response was received (DNS,
+// TLS, connection failures). The query string is omitted from the URI as it
+// may contain tokens. Errors returned by the API are passed through as is.
+func wrapStreamTransportError(operation, uri string, err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+
+	if i := strings.IndexByte(uri, '?'); i >= 0 {
+		uri = uri[:i]
+	}
+
+	return fmt.Errorf("%s %s: %w", operation, uri, err)
+}
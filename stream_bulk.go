@@ -0,0 +1,285 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// streamBulkConcurrency is the maximum number of requests made in parallel by
+// the bulk Stream helpers.
+const streamBulkConcurrency = 5
+
+// StreamBulkErrors holds the errors of a bulk Stream operation keyed by the
+// identifier of the item which failed.
+type StreamBulkErrors map[string]error
+
+func (e StreamBulkErrors) Error() string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", id, e[id]))
+	}
+
+	return fmt.Sprintf("%d of the bulk operations failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// StreamBulkOption is a functional option for configuring bulk Stream
+// operations.
+type StreamBulkOption func(opt *streamBulkOption)
+
+type streamBulkOption struct {
+	itemTimeout time.Duration
+	dryRun      *[]StreamBulkOperation
+}
+
+func newStreamBulkOption(opts ...StreamBulkOption) streamBulkOption {
+	opt := streamBulkOption{}
+	for _, o := range opts {
+		o(&opt)
+	}
+	return opt
+}
+
+// StreamBulkOperation is a request a bulk Stream operation makes for one of
+// its items.
+type StreamBulkOperation struct {
+	Method   string
+	URI      string
+	TargetID string
+}
+
+// WithStreamBulkItemTimeout limits the time each item of a bulk operation may
+// take, so that a slow item fails on its own rather than holding up the
+// whole operation.
+func WithStreamBulkItemTimeout(timeout time.Duration) StreamBulkOption {
+	return func(opt *streamBulkOption) {
+		opt.itemTimeout = timeout
+	}
+}
+
+// WithStreamBulkDryRun appends the requests a bulk operation would make to
+// change its items to plan instead of making them. Requests which only read,
+// such as listing the items to operate on, are still made. Bulk operations
+// which don't change anything ignore it.
+func WithStreamBulkDryRun(plan *[]StreamBulkOperation) StreamBulkOption {
+	return func(opt *streamBulkOption) {
+		opt.dryRun = plan
+	}
+}
+
+// streamBulk calls fn for every id with bounded concurrency. Items which have
+// not started when ctx is done fail with the context error. A StreamBulkErrors
+// is returned if any of the calls failed.
+func streamBulk(ctx context.Context, ids []string, fn func(ctx context.Context, id string) error, opts ...StreamBulkOption) error {
+	opt := newStreamBulkOption(opts...)
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = StreamBulkErrors{}
+		sem  = make(chan struct{}, streamBulkConcurrency)
+	)
+
+	recordError := func(id string, err error) {
+		mu.Lock()
+		errs[id] = err
+		mu.Unlock()
+	}
+
+	for _, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			recordError(id, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := ctx.Err(); err != nil {
+				recordError(id, err)
+				return
+			}
+
+			itemCtx := ctx
+			if opt.itemTimeout > 0 {
+				var cancel context.CancelFunc
+				itemCtx, cancel = context.WithTimeout(ctx, opt.itemTimeout)
+				defer cancel()
+			}
+
+			if err := fn(itemCtx, id); err != nil {
+				recordError(id, err)
+			}
+		}(id)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// streamBulkChange is streamBulk for operations which change their items. op
+// returns the request that changes an item, which is planned rather than
+// made by calling fn when WithStreamBulkDryRun is used.
+func streamBulkChange(ctx context.Context, ids []string, op func(id string) StreamBulkOperation, fn func(ctx context.Context, id string) error, opts ...StreamBulkOption) error {
+	opt := newStreamBulkOption(opts...)
+	if opt.dryRun != nil {
+		for _, id := range ids {
+			*opt.dryRun = append(*opt.dryRun, op(id))
+		}
+		return nil
+	}
+	return streamBulk(ctx, ids, fn, opts...)
+}
+
+// updateStreamVideoOperation is the request UpdateStreamVideo makes for a
+// video.
+func updateStreamVideoOperation(rc *ResourceContainer) func(id string) StreamBulkOperation {
+	return func(id string) StreamBulkOperation {
+		return StreamBulkOperation{Method: http.MethodPost, URI: fmt.Sprintf("%s/%s", streamBasePath(rc.Identifier), id), TargetID: id}
+	}
+}
+
+// GetStreamVideos fetches the details of multiple videos concurrently. Videos
+// which could not be fetched are omitted from the result and their errors are
+// returned as StreamBulkErrors keyed by video UID.
+func (api *API) GetStreamVideos(ctx context.Context, rc *ResourceContainer, videoIDs []string, opts ...StreamBulkOption) (map[string]StreamVideo, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return map[string]StreamVideo{}, err
+	}
+
+	var mu sync.Mutex
+	videos := make(map[string]StreamVideo, len(videoIDs))
+
+	err := streamBulk(ctx, videoIDs, func(ctx context.Context, id string) error {
+		video, err := api.StreamGetVideo(ctx, StreamParameters{AccountID: rc.Identifier, VideoID: id})
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		videos[id] = video
+		mu.Unlock()
+		return nil
+	}, opts...)
+
+	return videos, err
+}
+
+// SetStreamVideosCreator sets the creator of multiple videos concurrently.
+// Each video is fetched first and its meta, allowed origins, signed URL
+// requirement, thumbnail timestamp and scheduled deletion are sent back
+// with the update so that they are not reset. The errors of videos which
+// could not be updated are returned as StreamBulkErrors keyed by video UID.
+func (api *API) SetStreamVideosCreator(ctx context.Context, rc *ResourceContainer, videoIDs []string, creator string, opts ...StreamBulkOption) error {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return err
+	}
+
+	return streamBulkChange(ctx, videoIDs, updateStreamVideoOperation(rc), func(ctx context.Context, id string) error {
+		video, err := api.StreamGetVideo(ctx, StreamParameters{AccountID: rc.Identifier, VideoID: id})
+		if err != nil {
+			return err
+		}
+
+		update := streamVideoUpdate(video)
+		update.VideoID = id
+		update.Creator = creator
+		_, err = api.UpdateStreamVideo(ctx, rc, update)
+		if errors.Is(err, ErrAllowedOriginsWithoutSignedURLs) {
+			// The video was updated; the access settings are its own.
+			return nil
+		}
+		return err
+	}, opts...)
+}
+
+// TagStreamRecordings merges meta onto every video recorded from the given
+// live inputs, for example to tag all the recordings of an event. Keys of
+// meta replace existing keys of the same name and other meta is kept, as are
+// the creator, allowed origins, signed URL requirement, thumbnail timestamp
+// and scheduled deletion of each video.
+//
+// Live inputs whose videos could not be listed and videos which could not be
+// updated are returned as StreamBulkErrors keyed by live input or video UID.
+func (api *API) TagStreamRecordings(ctx context.Context, rc *ResourceContainer, liveInputIDs []string, meta map[string]interface{}, opts ...StreamBulkOption) error {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	videos := make(map[string]StreamVideo)
+
+	listErr := streamBulk(ctx, liveInputIDs, func(ctx context.Context, id string) error {
+		recordings, err := api.ListStreamLiveInputVideos(ctx, rc, id)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		for _, video := range recordings {
+			videos[video.UID] = video
+		}
+		mu.Unlock()
+		return nil
+	}, opts...)
+
+	videoIDs := make([]string, 0, len(videos))
+	for id := range videos {
+		videoIDs = append(videoIDs, id)
+	}
+	sort.Strings(videoIDs)
+
+	tagErr := streamBulkChange(ctx, videoIDs, updateStreamVideoOperation(rc), func(ctx context.Context, id string) error {
+		video := videos[id]
+
+		merged := make(map[string]interface{}, len(video.Meta)+len(meta))
+		for k, v := range video.Meta {
+			merged[k] = v
+		}
+		for k, v := range meta {
+			merged[k] = v
+		}
+
+		update := streamVideoUpdate(video)
+		update.VideoID = id
+		update.Meta = merged
+		_, err := api.UpdateStreamVideo(ctx, rc, update)
+		if errors.Is(err, ErrAllowedOriginsWithoutSignedURLs) {
+			// The video was updated; the access settings are its own.
+			return nil
+		}
+		return err
+	}, opts...)
+
+	errs := StreamBulkErrors{}
+	for _, err := range []error{listErr, tagErr} {
+		if bulkErrs, ok := err.(StreamBulkErrors); ok {
+			for id, err := range bulkErrs {
+				errs[id] = err
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream_GetStreamVideos(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ids := []string{"11111111111111111111111111111111", "22222222222222222222222222222222", "33333333333333333333333333333333"}
+	for _, id := range ids[:2] {
+		id := id
+		mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+id, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+			w.Header().Set("content-type", "application/json")
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s"}}`, id)
+		})
+	}
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+ids[2], func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10005, "message": "video not found"}], "messages": [], "result": null}`)
+	})
+
+	_, err := client.GetStreamVideos(context.Background(), AccountIdentifier(""), ids)
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	videos, err := client.GetStreamVideos(context.Background(), AccountIdentifier(testAccountID), ids)
+	assert.Equal(t, map[string]StreamVideo{
+		ids[0]: {UID: ids[0]},
+		ids[1]: {UID: ids[1]},
+	}, videos)
+
+	var bulkErrs StreamBulkErrors
+	require.True(t, errors.As(err, &bulkErrs))
+	assert.Len(t, bulkErrs, 1)
+
+	var notFound *NotFoundError
+	assert.True(t, errors.As(bulkErrs[ids[2]], &notFound))
+}
+
+func TestStream_GetStreamVideos_ContextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ids := []string{"11111111111111111111111111111111", "22222222222222222222222222222222"}
+	videos, err := client.GetStreamVideos(ctx, AccountIdentifier(testAccountID), ids)
+	assert.Empty(t, videos)
+
+	var bulkErrs StreamBulkErrors
+	require.True(t, errors.As(err, &bulkErrs))
+	for _, id := range ids {
+		assert.True(t, errors.Is(bulkErrs[id], context.Canceled))
+	}
+}
+
+func TestStream_GetStreamVideos_ItemTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ids := []string{"11111111111111111111111111111111", "22222222222222222222222222222222"}
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+ids[0], func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s"}}`, ids[0])
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+ids[1], func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	videos, err := client.GetStreamVideos(context.Background(), AccountIdentifier(testAccountID), ids, WithStreamBulkItemTimeout(50*time.Millisecond))
+	assert.Equal(t, map[string]StreamVideo{ids[0]: {UID: ids[0]}}, videos)
+
+	var bulkErrs StreamBulkErrors
+	require.True(t, errors.As(err, &bulkErrs))
+	assert.Len(t, bulkErrs, 1)
+	assert.True(t, errors.Is(bulkErrs[ids[1]], context.DeadlineExceeded))
+}
+
+func TestStream_SetStreamVideosCreator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ids := []string{"11111111111111111111111111111111", "22222222222222222222222222222222", "33333333333333333333333333333333"}
+	for _, id := range ids[:2] {
+		id := id
+		mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+id, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			switch r.Method {
+			case http.MethodGet:
+				fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "creator": "legacy", "meta": {"name": "%s.mp4"}, "allowedOrigins": ["example.com"], "requireSignedURLs": true}}`, id, id)
+			case http.MethodPost:
+				b, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, fmt.Sprintf(`{"creator": "creator-id_abcde12345", "meta": {"name": "%s.mp4"}, "allowedOrigins": ["example.com"], "requireSignedURLs": true}`, id), string(b))
+				fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "creator": "creator-id_abcde12345"}}`, id)
+			default:
+				t.Errorf("unexpected method %s", r.Method)
+			}
+		})
+	}
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+ids[2], func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10005, "message": "video not found"}], "messages": [], "result": null}`)
+	})
+
+	err := client.SetStreamVideosCreator(context.Background(), AccountIdentifier(""), ids, "creator-id_abcde12345")
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	err = client.SetStreamVideosCreator(context.Background(), AccountIdentifier(testAccountID), ids, "creator-id_abcde12345")
+
+	var bulkErrs StreamBulkErrors
+	require.True(t, errors.As(err, &bulkErrs))
+	assert.Len(t, bulkErrs, 1)
+
+	var notFound *NotFoundError
+	assert.True(t, errors.As(bulkErrs[ids[2]], &notFound))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = client.SetStreamVideosCreator(ctx, AccountIdentifier(testAccountID), ids[:1], "creator-id_abcde12345")
+	require.True(t, errors.As(err, &bulkErrs))
+	assert.True(t, errors.Is(bulkErrs[ids[0]], context.Canceled))
+}
+
+func TestStream_TagStreamRecordings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	inputs := map[string][]string{
+		"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa": {"11111111111111111111111111111111", "22222222222222222222222222222222"},
+		"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb": {"33333333333333333333333333333333"},
+	}
+	failingInput := "cccccccccccccccccccccccccccccccc"
+	failingVideo := "33333333333333333333333333333333"
+
+	for input, videos := range inputs {
+		input, videos := input, videos
+		mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+input+"/videos", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+			results := make([]string, 0, len(videos))
+			for _, id := range videos {
+				results = append(results, fmt.Sprintf(`{"uid": "%s", "liveInput": "%s", "creator": "broadcaster", "meta": {"name": "%s.mp4", "event": "old"}, "allowedOrigins": ["example.com"], "requireSignedURLs": true}`, id, input, id))
+			}
+			w.Header().Set("content-type", "application/json")
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": [%s]}`, strings.Join(results, ","))
+		})
+
+		for _, id := range videos {
+			id := id
+			mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+id, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+				w.Header().Set("content-type", "application/json")
+				if id == failingVideo {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"success": false, "errors": [{"code": 10000, "message": "bad request"}], "messages": [], "result": null}`)
+					return
+				}
+				b, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, fmt.Sprintf(`{"creator": "broadcaster", "meta": {"name": "%s.mp4", "event": "event-2023"}, "allowedOrigins": ["example.com"], "requireSignedURLs": true}`, id), string(b))
+				fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s"}}`, id)
+			})
+		}
+	}
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+failingInput+"/videos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10003, "message": "live input not found"}], "messages": [], "result": null}`)
+	})
+
+	ids := []string{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", failingInput}
+	meta := map[string]interface{}{"event": "event-2023"}
+
+	err := client.TagStreamRecordings(context.Background(), AccountIdentifier(""), ids, meta)
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	err = client.TagStreamRecordings(context.Background(), AccountIdentifier(testAccountID), ids, meta)
+
+	var bulkErrs StreamBulkErrors
+	require.True(t, errors.As(err, &bulkErrs))
+	assert.Len(t, bulkErrs, 2)
+
+	var notFound *NotFoundError
+	assert.True(t, errors.As(bulkErrs[failingInput], &notFound))
+	var requestErr *RequestError
+	assert.True(t, errors.As(bulkErrs[failingVideo], &requestErr))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = client.TagStreamRecordings(ctx, AccountIdentifier(testAccountID), ids[:1], meta)
+	require.True(t, errors.As(err, &bulkErrs))
+	assert.True(t, errors.Is(bulkErrs[ids[0]], context.Canceled))
+}
+
+func TestStream_StreamBulk_StrictStreamAccessSettings(t *testing.T) {
+	setup(UsingStrictStreamAccessSettings(true))
+	defer teardown()
+
+	updates := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/videos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": [{"uid": "%s", "liveInput": "%s", "allowedOrigins": ["example.com"]}]}`, testVideoID, testLiveInputID)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		if r.Method == http.MethodPost {
+			updates++
+		}
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "allowedOrigins": ["example.com"]}}`, testVideoID)
+	})
+
+	// The videos allow origins without signed URLs, which strict mode reports
+	// for single updates, but they were updated so no item has failed.
+	err := client.SetStreamVideosCreator(context.Background(), AccountIdentifier(testAccountID), []string{testVideoID}, "creator-id_abcde12345")
+	assert.NoError(t, err)
+
+	err = client.TagStreamRecordings(context.Background(), AccountIdentifier(testAccountID), []string{testLiveInputID}, map[string]interface{}{"event": "event-2023"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updates)
+}
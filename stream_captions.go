@@ -0,0 +1,211 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// ErrMissingCaptionLanguage is for when Language is required but missing.
+	ErrMissingCaptionLanguage = errors.New("required caption language missing")
+	// ErrInvalidStreamCaption is for when a caption file is not valid for its format.
+	ErrInvalidStreamCaption = errors.New("invalid caption file")
+	// ErrUnsupportedCaptionFormat is for when a caption format is not supported.
+	ErrUnsupportedCaptionFormat = errors.New("unsupported caption format")
+)
+
+// StreamCaptionFormat is the format of a caption file.
+type StreamCaptionFormat string
+
+const (
+	// StreamCaptionFormatVTT is a WebVTT caption file, which is uploaded as-is.
+	StreamCaptionFormatVTT StreamCaptionFormat = "vtt"
+	// StreamCaptionFormatSRT is a SubRip caption file, which is converted to
+	// WebVTT before it is uploaded.
+	StreamCaptionFormatSRT StreamCaptionFormat = "srt"
+)
+
+// srtTimingLine matches the timing line of an SRT cue, capturing the start
+// and end timestamps and any trailing cue settings.
+var srtTimingLine = regexp.MustCompile(`^(\d{2,}:\d{2}:\d{2})[,.](\d{3}) --> (\d{2,}:\d{2}:\d{2})[,.](\d{3})(.*)$`)
+
+// vttSignature matches the signature a WebVTT file must start with.
+var vttSignature = regexp.MustCompile(`^\x{FEFF}?WEBVTT([ \t\r\n]|$)`)
+
+// StreamVideoCaption represents a caption track of a video.
+type StreamVideoCaption struct {
+	Label    string `json:"label,omitempty"`
+	Language string `json:"language,omitempty"`
+}
+
+// UploadStreamVideoCaptionParameters are the parameters used when uploading a
+// caption file for a video.
+type UploadStreamVideoCaptionParameters struct {
+	VideoID  string
+	Language string
+	Caption  []byte
+
+	// Format is the format of Caption. Defaults to StreamCaptionFormatVTT.
+	Format StreamCaptionFormat
+}
+
+// StreamVideoCaptionResponse represents an API response of a caption track.
+type StreamVideoCaptionResponse struct {
+	Response
+	Result StreamVideoCaption `json:"result,omitempty"`
+}
+
+// UploadStreamVideoCaption uploads a caption file for a video in the given
+// language, replacing any existing caption for that language. SRT captions are
+// converted to WebVTT before they are uploaded.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-subtitles/captions-upload-captions-or-subtitles
+func (api *API) UploadStreamVideoCaption(ctx context.Context, rc *ResourceContainer, params UploadStreamVideoCaptionParameters) (StreamVideoCaption, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamVideoCaption{}, err
+	}
+
+	if params.VideoID == "" {
+		return StreamVideoCaption{}, ErrMissingVideoID
+	}
+
+	if params.Language == "" {
+		return StreamVideoCaption{}, ErrMissingCaptionLanguage
+	}
+
+	var vtt []byte
+	switch params.Format {
+	case "", StreamCaptionFormatVTT:
+		if err := validateVTT(params.Caption); err != nil {
+			return StreamVideoCaption{}, err
+		}
+		vtt = params.Caption
+	case StreamCaptionFormatSRT:
+		var err error
+		if vtt, err = convertSRTToVTT(params.Caption); err != nil {
+			return StreamVideoCaption{}, err
+		}
+	default:
+		return StreamVideoCaption{}, fmt.Errorf("%w: %q", ErrUnsupportedCaptionFormat, params.Format)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	formFile, err := writer.CreateFormFile("file", params.Language+".vtt")
+	if err != nil {
+		return StreamVideoCaption{}, err
+	}
+	if _, err := formFile.Write(vtt); err != nil {
+		return StreamVideoCaption{}, err
+	}
+	if err := writer.Close(); err != nil {
+		return StreamVideoCaption{}, err
+	}
+
+	uri := fmt.Sprintf("%s/%s/captions/%s", streamBasePath(rc.Identifier), params.VideoID, url.PathEscape(params.Language))
+	res, err := api.makeRequestContextWithHeaders(ctx, http.MethodPut, uri, body, http.Header{
+		"Accept":       []string{"application/json"},
+		"Content-Type": []string{writer.FormDataContentType()},
+	})
+	api.auditStream(ctx, StreamAuditEvent{Operation: "UploadStreamVideoCaption", AccountID: rc.Identifier, VideoID: params.VideoID}, err)
+	if err != nil {
+		return StreamVideoCaption{}, wrapStreamTransportError("UploadStreamVideoCaption", uri, err)
+	}
+
+	var r StreamVideoCaptionResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return StreamVideoCaption{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	return r.Result, nil
+}
+
+// GetStreamVideoCaption writes the WebVTT content of the caption of a video in
+// the given language to w as it is received. A *NotFoundError is returned when
+// the video has no caption in that language. If copying fails part of the
+// caption may already have been written to w.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-subtitles/captions-get-vtt-caption-or-subtitle
+func (api *API) GetStreamVideoCaption(ctx context.Context, rc *ResourceContainer, videoID, language string, w io.Writer) error {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return err
+	}
+
+	if videoID == "" {
+		return ErrMissingVideoID
+	}
+
+	if language == "" {
+		return ErrMissingCaptionLanguage
+	}
+
+	uri := fmt.Sprintf("%s/%s/captions/%s/vtt", streamBasePath(rc.Identifier), videoID, url.PathEscape(language))
+	_, err := api.makeRequestContextTo(ctx, http.MethodGet, uri, nil, http.Header{
+		"Accept": []string{"text/vtt"},
+	}, w)
+	if err != nil {
+		return wrapStreamTransportError("GetStreamVideoCaption", uri, err)
+	}
+	return nil
+}
+
+// validateVTT checks that caption starts with the WebVTT signature.
+func validateVTT(caption []byte) error {
+	if !vttSignature.Match(caption) {
+		return fmt.Errorf("%w: missing WEBVTT header", ErrInvalidStreamCaption)
+	}
+	return nil
+}
+
+// convertSRTToVTT converts a SubRip caption file to WebVTT. Each cue must have
+// a timing line, optionally preceded by a numeric identifier, and at least one
+// line of text.
+func convertSRTToVTT(srt []byte) ([]byte, error) {
+	text := strings.TrimPrefix(string(srt), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	var out strings.Builder
+	out.WriteString("WEBVTT\n")
+
+	cues := 0
+	for _, block := range strings.Split(text, "\n\n") {
+		lines := strings.Split(strings.Trim(block, "\n"), "\n")
+		if len(lines) == 1 && strings.TrimSpace(lines[0]) == "" {
+			continue
+		}
+		cues++
+
+		if !srtTimingLine.MatchString(lines[0]) {
+			lines = lines[1:]
+		}
+		if len(lines) == 0 || !srtTimingLine.MatchString(lines[0]) {
+			return nil, fmt.Errorf("%w: cue %d has no timing line", ErrInvalidStreamCaption, cues)
+		}
+		if len(lines) < 2 {
+			return nil, fmt.Errorf("%w: cue %d has no text", ErrInvalidStreamCaption, cues)
+		}
+
+		out.WriteString("\n")
+		out.WriteString(srtTimingLine.ReplaceAllString(lines[0], "$1.$2 --> $3.$4$5"))
+		out.WriteString("\n")
+		for _, line := range lines[1:] {
+			out.WriteString(line)
+			out.WriteString("\n")
+		}
+	}
+
+	if cues == 0 {
+		return nil, fmt.Errorf("%w: no cues", ErrInvalidStreamCaption)
+	}
+	return []byte(out.String()), nil
+}
@@ -0,0 +1,187 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSRTCaption = "1\r\n00:00:01,000 --> 00:00:04,500\r\nHello there.\r\n\r\n2\r\n00:00:05,250 --> 00:00:07,000 X1:40 X2:600\r\nThis line\r\nwraps.\r\n"
+
+const testVTTCaption = `WEBVTT
+
+00:00:01.000 --> 00:00:04.500
+Hello there.
+
+00:00:05.250 --> 00:00:07.000 X1:40 X2:600
+This line
+wraps.
+`
+
+func TestStream_ConvertSRTToVTT(t *testing.T) {
+	out, err := convertSRTToVTT([]byte("\ufeff" + testSRTCaption))
+	require.NoError(t, err)
+	assert.Equal(t, testVTTCaption, string(out))
+
+	for name, srt := range map[string]string{
+		"empty":          "",
+		"no timing line": "1\nHello there.\n",
+		"bad timestamp":  "1\n00:00:01 --> 00:00:04,500\nHello there.\n",
+		"no text":        "1\n00:00:01,000 --> 00:00:04,500\n",
+		"vtt":            testVTTCaption,
+	} {
+		_, err := convertSRTToVTT([]byte(srt))
+		assert.ErrorIs(t, err, ErrInvalidStreamCaption, name)
+	}
+}
+
+func TestStream_UploadStreamVideoCaption(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/captions/en", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method, "Expected method 'PUT', got %s", r.Method)
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+		assert.Equal(t, "en.vtt", header.Filename)
+
+		b, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, testVTTCaption, string(b))
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "label": "English",
+    "language": "en"
+  }
+}`)
+	})
+
+	_, err := client.UploadStreamVideoCaption(context.Background(), AccountIdentifier(""), UploadStreamVideoCaptionParameters{})
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	_, err = client.UploadStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoCaptionParameters{})
+	assert.Equal(t, ErrMissingVideoID, err)
+
+	_, err = client.UploadStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoCaptionParameters{VideoID: testVideoID})
+	assert.Equal(t, ErrMissingCaptionLanguage, err)
+
+	params := UploadStreamVideoCaptionParameters{VideoID: testVideoID, Language: "en", Caption: []byte(testSRTCaption)}
+	_, err = client.UploadStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), params)
+	assert.ErrorIs(t, err, ErrInvalidStreamCaption, "SRT should not be accepted as VTT")
+
+	params.Format = "ass"
+	_, err = client.UploadStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), params)
+	assert.ErrorIs(t, err, ErrUnsupportedCaptionFormat)
+
+	want := StreamVideoCaption{Label: "English", Language: "en"}
+
+	params.Format = StreamCaptionFormatSRT
+	out, err := client.UploadStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), params)
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, out)
+	}
+
+	out, err = client.UploadStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoCaptionParameters{
+		VideoID:  testVideoID,
+		Language: "en",
+		Caption:  []byte(testVTTCaption),
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, out)
+	}
+}
+
+func TestStream_GetStreamVideoCaption(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/captions/en/vtt", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "text/vtt")
+		fmt.Fprint(w, testVTTCaption)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/captions/fr/vtt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10005, "message": "Caption not found"}], "messages": [], "result": null}`)
+	})
+
+	var buf bytes.Buffer
+	err := client.GetStreamVideoCaption(context.Background(), AccountIdentifier(""), testVideoID, "en", &buf)
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	err = client.GetStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), "", "en", &buf)
+	assert.Equal(t, ErrMissingVideoID, err)
+
+	err = client.GetStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), testVideoID, "", &buf)
+	assert.Equal(t, ErrMissingCaptionLanguage, err)
+
+	err = client.GetStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), testVideoID, "en", &buf)
+	if assert.NoError(t, err) {
+		assert.Equal(t, testVTTCaption, buf.String())
+	}
+
+	buf.Reset()
+	err = client.GetStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), testVideoID, "fr", &buf)
+	var notFoundError *NotFoundError
+	assert.ErrorAs(t, err, &notFoundError)
+	assert.Empty(t, buf.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = client.GetStreamVideoCaption(ctx, AccountIdentifier(testAccountID), testVideoID, "en", &buf)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, buf.String())
+}
+
+// notifyingWriter closes written on the first write.
+type notifyingWriter struct {
+	buf     bytes.Buffer
+	written chan struct{}
+}
+
+func (w *notifyingWriter) Write(p []byte) (int, error) {
+	if w.buf.Len() == 0 {
+		close(w.written)
+	}
+	return w.buf.Write(p)
+}
+
+func TestStream_GetStreamVideoCaption_Streams(t *testing.T) {
+	setup()
+	defer teardown()
+
+	out := &notifyingWriter{written: make(chan struct{})}
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/captions/en/vtt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/vtt")
+		fmt.Fprint(w, "WEBVTT\n\n")
+		w.(http.Flusher).Flush()
+
+		// The rest of the caption is only sent once the start has been
+		// written, which would block forever if the body was read first.
+		select {
+		case <-out.written:
+		case <-time.After(5 * time.Second):
+			t.Error("caption was not written before the response completed")
+		}
+		fmt.Fprint(w, "00:00:01.000 --> 00:00:04.500\nHello there.\n")
+	})
+
+	err := client.GetStreamVideoCaption(context.Background(), AccountIdentifier(testAccountID), testVideoID, "en", out)
+	require.NoError(t, err)
+	assert.Equal(t, "WEBVTT\n\n00:00:01.000 --> 00:00:04.500\nHello there.\n", out.buf.String())
+}
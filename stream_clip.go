@@ -0,0 +1,135 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// ErrInvalidStreamClipRange is for when the end of a clip is not after its start.
+	ErrInvalidStreamClipRange = errors.New("clip end time must be after its start time")
+	// ErrStreamClipOutOfRange is for when a clip ends after the end of its source video.
+	ErrStreamClipOutOfRange = errors.New("clip end time exceeds the source video duration")
+)
+
+// CreateStreamClipParameters are the parameters used when clipping a video.
+type CreateStreamClipParameters struct {
+	ClippedFromVideoUID   string                   `json:"clippedFromVideoUID"`
+	StartTimeSeconds      int                      `json:"startTimeSeconds"`
+	EndTimeSeconds        int                      `json:"endTimeSeconds"`
+	AllowedOrigins        []string                 `json:"allowedOrigins,omitempty"`
+	RequireSignedURLs     bool                     `json:"requireSignedURLs,omitempty"`
+	Creator               string                   `json:"creator,omitempty"`
+	MaxDurationSeconds    int                      `json:"maxDurationSeconds,omitempty"`
+	ThumbnailTimestampPct float64                  `json:"thumbnailTimestampPct,omitempty"`
+	Watermark             *UploadVideoURLWatermark `json:"watermark,omitempty"`
+
+	// ValidateSourceDuration fetches the source video to check that the clip
+	// ends within its duration before creating the clip. The check is skipped
+	// when the duration of the source video is not known yet.
+	ValidateSourceDuration bool `json:"-"`
+
+	// Meta is set on the clip once it is created, as the clip endpoint does
+	// not accept meta.
+	Meta map[string]interface{} `json:"-"`
+
+	// InheritSourceMeta fetches the source video and sets its meta on the
+	// clip, with the keys of Meta taking precedence.
+	InheritSourceMeta bool `json:"-"`
+}
+
+// CreateStreamClip creates a clip of a video. AllowedOrigins and
+// RequireSignedURLs protect the clip as soon as it is created.
+//
+// When meta is set, directly or inherited from the source video, the clip is
+// updated with it after it is created, sending back its creator and access
+// settings so that they are kept. If that update fails the created clip is
+// returned along with the error.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-video-clipping-clip-videos-given-a-start-and-end-time
+func (api *API) CreateStreamClip(ctx context.Context, rc *ResourceContainer, params CreateStreamClipParameters) (StreamVideo, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamVideo{}, err
+	}
+
+	if params.ClippedFromVideoUID == "" {
+		return StreamVideo{}, ErrMissingVideoID
+	}
+
+	if params.EndTimeSeconds <= params.StartTimeSeconds {
+		return StreamVideo{}, ErrInvalidStreamClipRange
+	}
+
+	meta := params.Meta
+	if params.ValidateSourceDuration || params.InheritSourceMeta {
+		source, err := api.StreamGetVideo(ctx, StreamParameters{AccountID: rc.Identifier, VideoID: params.ClippedFromVideoUID})
+		if err != nil {
+			return StreamVideo{}, err
+		}
+		if params.ValidateSourceDuration && source.Duration > 0 && float64(params.EndTimeSeconds) > source.Duration {
+			return StreamVideo{}, fmt.Errorf("%w: %ds is after %gs", ErrStreamClipOutOfRange, params.EndTimeSeconds, source.Duration)
+		}
+		if params.InheritSourceMeta && len(source.Meta) > 0 {
+			meta = make(map[string]interface{}, len(source.Meta)+len(params.Meta))
+			for k, v := range source.Meta {
+				meta[k] = v
+			}
+			for k, v := range params.Meta {
+				meta[k] = v
+			}
+		}
+	}
+
+	meta, err := normalizeStreamMeta(meta)
+	if err != nil {
+		return StreamVideo{}, err
+	}
+
+	origins, err := normalizeStreamAllowedOrigins(params.AllowedOrigins)
+	if err != nil {
+		return StreamVideo{}, err
+	}
+	params.AllowedOrigins = origins
+
+	warning := api.checkStreamAllowedOrigins("CreateStreamClip", params.AllowedOrigins, params.RequireSignedURLs)
+
+	uri := streamBasePath(rc.Identifier) + "/clip"
+	res, err := api.makeRequestContext(ctx, http.MethodPost, uri, params)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "CreateStreamClip", AccountID: rc.Identifier, VideoID: params.ClippedFromVideoUID}, err)
+	if err != nil {
+		return StreamVideo{}, wrapStreamTransportError("CreateStreamClip", uri, err)
+	}
+
+	var r StreamVideoResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return StreamVideo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	if len(meta) == 0 {
+		return r.Result, warning
+	}
+
+	// The access settings the clip was created with are sent back so that
+	// the update does not reset them.
+	update := streamVideoUpdate(r.Result)
+	update.Meta = meta
+	update.RequireSignedURLs = &params.RequireSignedURLs
+	if params.Creator != "" {
+		update.Creator = params.Creator
+	}
+	if len(params.AllowedOrigins) > 0 {
+		update.AllowedOrigins = params.AllowedOrigins
+	}
+	if params.ThumbnailTimestampPct != 0 {
+		update.ThumbnailTimestampPct = params.ThumbnailTimestampPct
+	}
+	clip, err := api.UpdateStreamVideo(ctx, rc, update)
+	if err != nil && !errors.Is(err, ErrAllowedOriginsWithoutSignedURLs) {
+		return r.Result, err
+	}
+	return clip, warning
+}
@@ -0,0 +1,256 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream_CreateStreamClip(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const clipID = "5bde6b29b9e52b1bbc8c4e46f7b28f6e"
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/clip", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, fmt.Sprintf(`{
+  "clippedFromVideoUID": "%s",
+  "startTimeSeconds": 10,
+  "endTimeSeconds": 15,
+  "allowedOrigins": ["example.com", "*.example.net"],
+  "requireSignedURLs": true
+}`, testVideoID), string(b))
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "uid": "%s",
+    "clippedFromVideoUID": "%s",
+    "allowedOrigins": ["example.com", "*.example.net"],
+    "requireSignedURLs": true,
+    "status": {"state": "queued"}
+  }
+}`, clipID, testVideoID)
+	})
+
+	_, err := client.CreateStreamClip(context.Background(), AccountIdentifier(""), CreateStreamClipParameters{})
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	_, err = client.CreateStreamClip(context.Background(), AccountIdentifier(testAccountID), CreateStreamClipParameters{})
+	assert.Equal(t, ErrMissingVideoID, err)
+
+	_, err = client.CreateStreamClip(context.Background(), AccountIdentifier(testAccountID), CreateStreamClipParameters{
+		ClippedFromVideoUID: testVideoID,
+		StartTimeSeconds:    15,
+		EndTimeSeconds:      10,
+	})
+	assert.Equal(t, ErrInvalidStreamClipRange, err)
+
+	_, err = client.CreateStreamClip(context.Background(), AccountIdentifier(testAccountID), CreateStreamClipParameters{
+		ClippedFromVideoUID: testVideoID,
+		StartTimeSeconds:    10,
+		EndTimeSeconds:      15,
+		AllowedOrigins:      []string{"example.com/videos"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidStreamAllowedOrigin)
+
+	out, err := client.CreateStreamClip(context.Background(), AccountIdentifier(testAccountID), CreateStreamClipParameters{
+		ClippedFromVideoUID: testVideoID,
+		StartTimeSeconds:    10,
+		EndTimeSeconds:      15,
+		AllowedOrigins:      []string{"https://Example.com", "*.example.net", "example.com"},
+		RequireSignedURLs:   true,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StreamVideo{
+			UID:                 clipID,
+			ClippedFromVideoUID: testVideoID,
+			AllowedOrigins:      []string{"example.com", "*.example.net"},
+			RequireSignedURLs:   true,
+			Status:              StreamVideoStatus{State: "queued"},
+		}, out)
+		assert.True(t, out.IsClip())
+	}
+}
+
+func TestStream_CreateStreamClip_ValidateSourceDuration(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const clipID = "5bde6b29b9e52b1bbc8c4e46f7b28f6e"
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "duration": 30.5}}`, testVideoID)
+	})
+
+	clips := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/clip", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		clips++
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "clippedFromVideoUID": "%s"}}`, clipID, testVideoID)
+	})
+
+	params := CreateStreamClipParameters{
+		ClippedFromVideoUID:    testVideoID,
+		StartTimeSeconds:       25,
+		EndTimeSeconds:         31,
+		ValidateSourceDuration: true,
+	}
+	_, err := client.CreateStreamClip(context.Background(), AccountIdentifier(testAccountID), params)
+	assert.ErrorIs(t, err, ErrStreamClipOutOfRange)
+	assert.Equal(t, 0, clips)
+
+	params.EndTimeSeconds = 30
+	out, err := client.CreateStreamClip(context.Background(), AccountIdentifier(testAccountID), params)
+	if assert.NoError(t, err) {
+		assert.Equal(t, clipID, out.UID)
+	}
+	assert.Equal(t, 1, clips)
+}
+
+func TestStream_CreateStreamClip_InheritSourceMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const clipID = "5bde6b29b9e52b1bbc8c4e46f7b28f6e"
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {"uid": "%s", "meta": {"event": "Final", "name": "final.mp4"}}
+}`, testVideoID)
+	})
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/clip", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.NotContains(t, string(b), "meta", "meta should not be sent to the clip endpoint")
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "clippedFromVideoUID": "%s"}}`, clipID, testVideoID)
+	})
+
+	var updatedMeta map[string]interface{}
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+clipID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		var params UpdateStreamVideoParameters
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		updatedMeta = params.Meta
+
+		w.Header().Set("content-type", "application/json")
+		b, err := json.Marshal(params.Meta)
+		require.NoError(t, err)
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "clippedFromVideoUID": "%s", "meta": %s}}`, clipID, testVideoID, b)
+	})
+
+	out, err := client.CreateStreamClip(context.Background(), AccountIdentifier(testAccountID), CreateStreamClipParameters{
+		ClippedFromVideoUID: testVideoID,
+		StartTimeSeconds:    10,
+		EndTimeSeconds:      15,
+		InheritSourceMeta:   true,
+		Meta:                map[string]interface{}{"name": "highlight.mp4"},
+	})
+	if assert.NoError(t, err) {
+		want := map[string]interface{}{"event": "Final", "name": "highlight.mp4"}
+		assert.Equal(t, want, updatedMeta)
+		assert.Equal(t, want, out.Meta)
+		assert.Equal(t, clipID, out.UID)
+	}
+
+	updatedMeta = nil
+	out, err = client.CreateStreamClip(context.Background(), AccountIdentifier(testAccountID), CreateStreamClipParameters{
+		ClippedFromVideoUID: testVideoID,
+		StartTimeSeconds:    10,
+		EndTimeSeconds:      15,
+		InheritSourceMeta:   true,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"event": "Final", "name": "final.mp4"}, updatedMeta)
+		assert.Equal(t, clipID, out.UID)
+	}
+
+	updatedMeta = nil
+	_, err = client.CreateStreamClip(context.Background(), AccountIdentifier(testAccountID), CreateStreamClipParameters{
+		ClippedFromVideoUID: testVideoID,
+		StartTimeSeconds:    10,
+		EndTimeSeconds:      15,
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, updatedMeta, "the clip should not be updated without meta")
+}
+
+func TestStream_CreateStreamClip_MetaKeepsAccessSettings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const clipID = "5bde6b29b9e52b1bbc8c4e46f7b28f6e"
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/clip", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "uid": "%s",
+    "clippedFromVideoUID": "%s",
+    "creator": "creator-id_abcde12345",
+    "allowedOrigins": ["example.com"],
+    "requireSignedURLs": true
+  }
+}`, clipID, testVideoID)
+	})
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+clipID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+  "creator": "creator-id_abcde12345",
+  "meta": {"name": "highlight.mp4"},
+  "allowedOrigins": ["example.com"],
+  "requireSignedURLs": true
+}`, string(b))
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "meta": {"name": "highlight.mp4"}, "allowedOrigins": ["example.com"], "requireSignedURLs": true}}`, clipID)
+	})
+
+	out, err := client.CreateStreamClip(context.Background(), AccountIdentifier(testAccountID), CreateStreamClipParameters{
+		ClippedFromVideoUID: testVideoID,
+		StartTimeSeconds:    10,
+		EndTimeSeconds:      15,
+		AllowedOrigins:      []string{"example.com"},
+		RequireSignedURLs:   true,
+		Creator:             "creator-id_abcde12345",
+		Meta:                map[string]interface{}{"name": "highlight.mp4"},
+	})
+	require.NoError(t, err)
+	assert.True(t, out.RequireSignedURLs)
+	assert.Equal(t, []string{"example.com"}, out.AllowedOrigins)
+}
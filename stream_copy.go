@@ -0,0 +1,122 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// CopyStreamVideoToAccountParameters are the parameters used when copying a
+// video to another account.
+type CopyStreamVideoToAccountParameters struct {
+	VideoID              string
+	DestinationAccountID string
+
+	// WaitForReady waits for the copy to be ready to stream before returning.
+	WaitForReady bool
+
+	// Progress, if set, is called with the processing progress of the copy,
+	// as a percentage, while waiting for it to be ready.
+	Progress func(pctComplete float64)
+
+	// PollInterval is the interval used when waiting for the MP4 download of
+	// the source video and, if WaitForReady is set, for the copy to be ready.
+	// Defaults to 2 seconds.
+	PollInterval time.Duration
+}
+
+// CopyStreamVideoToAccount copies a video from the account of rc to another
+// account by uploading the MP4 download of the source video into the
+// destination account. The download is generated and waited for if needed.
+//
+// The meta, creator, allowed origins, thumbnail timestamp and signed URL
+// requirement of the source video are kept. When the source video requires
+// signed URLs, a downloadable signed token is used to fetch it.
+func (api *API) CopyStreamVideoToAccount(ctx context.Context, rc *ResourceContainer, params CopyStreamVideoToAccountParameters) (StreamVideo, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamVideo{}, err
+	}
+
+	if err := validateAccountID(params.DestinationAccountID); err != nil {
+		return StreamVideo{}, err
+	}
+
+	if params.VideoID == "" {
+		return StreamVideo{}, ErrMissingVideoID
+	}
+
+	source, err := api.StreamGetVideo(ctx, StreamParameters{AccountID: rc.Identifier, VideoID: params.VideoID})
+	if err != nil {
+		return StreamVideo{}, err
+	}
+
+	download, err := api.streamVideoDownloadURL(ctx, rc, params.VideoID, params.PollInterval)
+	if err != nil {
+		return StreamVideo{}, err
+	}
+
+	if source.RequireSignedURLs {
+		token, err := api.StreamCreateSignedURL(ctx, StreamSignedURLParameters{
+			AccountID:    rc.Identifier,
+			VideoID:      params.VideoID,
+			Downloadable: true,
+		})
+		if err != nil {
+			return StreamVideo{}, err
+		}
+		download = strings.Replace(download, params.VideoID, token, 1)
+	}
+
+	video, err := api.StreamUploadFromURL(ctx, StreamUploadFromURLParameters{
+		AccountID:             params.DestinationAccountID,
+		URL:                   download,
+		Creator:               source.Creator,
+		ThumbnailTimestampPct: source.ThumbnailTimestampPct,
+		AllowedOrigins:        source.AllowedOrigins,
+		RequireSignedURLs:     source.RequireSignedURLs,
+		Meta:                  source.Meta,
+	})
+	if err != nil && !errors.Is(err, ErrAllowedOriginsWithoutSignedURLs) {
+		return StreamVideo{}, err
+	}
+	warning := err
+
+	if !params.WaitForReady {
+		return video, warning
+	}
+	video, err = api.WaitForStreamVideoReadyWithProgress(ctx, AccountIdentifier(params.DestinationAccountID), video.UID, params.PollInterval, params.Progress)
+	if err != nil {
+		return video, err
+	}
+	return video, warning
+}
+
+// streamVideoDownloadURL returns the URL of the MP4 download of a video,
+// creating the download and waiting for it when it is not ready yet.
+func (api *API) streamVideoDownloadURL(ctx context.Context, rc *ResourceContainer, videoID string, interval time.Duration) (string, error) {
+	downloads, err := api.GetStreamVideoDownloads(ctx, rc, videoID)
+	if err != nil {
+		return "", err
+	}
+
+	if downloads.Default.Status == "ready" {
+		return downloads.Default.URL, nil
+	}
+
+	if downloads.Default.Status == "" || downloads.Default.Status == "error" {
+		if _, err := api.CreateStreamVideoDownload(ctx, rc, videoID); err != nil {
+			return "", err
+		}
+	}
+
+	download, err := api.WaitForStreamVideoDownload(ctx, rc, WaitForStreamVideoDownloadParameters{
+		VideoID:     videoID,
+		MinInterval: interval,
+		MaxInterval: interval,
+	})
+	if err != nil {
+		return "", err
+	}
+	return download.URL, nil
+}
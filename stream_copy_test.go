@@ -0,0 +1,110 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream_CopyStreamVideoToAccount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const (
+		destinationAccountID = "8d4e0a7c4f8d4b6e9f1a2b3c4d5e6f70"
+		copyID               = "5bde6b29b9e52b1bbc8c4e46f7b28f6e"
+		token                = "signed-token"
+	)
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "uid": "%s",
+    "creator": "creator-id_abcde12345",
+    "allowedOrigins": ["example.com"],
+    "requireSignedURLs": true,
+    "thumbnailTimestampPct": 0.529241,
+    "meta": {"name": "video12345.mp4"},
+    "readyToStream": true
+  }
+}`, testVideoID)
+	})
+
+	downloadReady := false
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/downloads", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		switch {
+		case r.Method == http.MethodPost:
+			downloadReady = true
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"default": {"status": "inprogress", "percentComplete": 0}}}`)
+		case downloadReady:
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"default": {"status": "ready", "url": "https://customer-m033z5x00ks6nunl.cloudflarestream.com/%s/downloads/default.mp4", "percentComplete": 100}}}`, testVideoID)
+		default:
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {}}`)
+		}
+	})
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		var params StreamSignedURLParameters
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		assert.True(t, params.Downloadable)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"token": "%s"}}`, token)
+	})
+
+	mux.HandleFunc("/accounts/"+destinationAccountID+"/stream/copy", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		var params StreamUploadFromURLParameters
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		assert.Equal(t, "https://customer-m033z5x00ks6nunl.cloudflarestream.com/"+token+"/downloads/default.mp4", params.URL)
+		assert.Equal(t, "creator-id_abcde12345", params.Creator)
+		assert.Equal(t, []string{"example.com"}, params.AllowedOrigins)
+		assert.True(t, params.RequireSignedURLs)
+		assert.Equal(t, 0.529241, params.ThumbnailTimestampPct)
+		assert.Equal(t, map[string]interface{}{"name": "video12345.mp4"}, params.Meta)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "readyToStream": false}}`, copyID)
+	})
+
+	mux.HandleFunc("/accounts/"+destinationAccountID+"/stream/"+copyID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "readyToStream": true}}`, copyID)
+	})
+
+	_, err := client.CopyStreamVideoToAccount(context.Background(), AccountIdentifier(""), CopyStreamVideoToAccountParameters{})
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	_, err = client.CopyStreamVideoToAccount(context.Background(), AccountIdentifier(testAccountID), CopyStreamVideoToAccountParameters{})
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	_, err = client.CopyStreamVideoToAccount(context.Background(), AccountIdentifier(testAccountID), CopyStreamVideoToAccountParameters{DestinationAccountID: destinationAccountID})
+	assert.Equal(t, ErrMissingVideoID, err)
+
+	out, err := client.CopyStreamVideoToAccount(context.Background(), AccountIdentifier(testAccountID), CopyStreamVideoToAccountParameters{
+		VideoID:              testVideoID,
+		DestinationAccountID: destinationAccountID,
+		WaitForReady:         true,
+		PollInterval:         time.Millisecond,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StreamVideo{UID: copyID, ReadyToStream: true}, out)
+	}
+	assert.True(t, downloadReady, "expected the download to be created")
+}
@@ -0,0 +1,156 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	// defaultStreamPollMinInterval is the delay before the first poll when
+	// waiting on a video.
+	defaultStreamPollMinInterval = 2 * time.Second
+
+	// defaultStreamPollMaxInterval caps the delay between polls when waiting
+	// on a video.
+	defaultStreamPollMaxInterval = 30 * time.Second
+)
+
+var (
+	// ErrStreamVideoDownloadFailed is for when generating the MP4 download of a video failed.
+	ErrStreamVideoDownloadFailed = errors.New("stream video download failed")
+)
+
+// StreamVideoDownloads represents the downloads available for a video.
+type StreamVideoDownloads struct {
+	Default StreamVideoDownload `json:"default,omitempty"`
+}
+
+// StreamVideoDownload represents the MP4 download of a video.
+type StreamVideoDownload struct {
+	Status          string  `json:"status,omitempty"`
+	URL             string  `json:"url,omitempty"`
+	PercentComplete float64 `json:"percentComplete,omitempty"`
+}
+
+// StreamVideoDownloadsResponse represents an API response of the downloads
+// of a video.
+type StreamVideoDownloadsResponse struct {
+	Response
+	Result StreamVideoDownloads `json:"result,omitempty"`
+}
+
+// WaitForStreamVideoDownloadParameters are the parameters used when waiting
+// for the MP4 download of a video to be ready.
+type WaitForStreamVideoDownloadParameters struct {
+	VideoID string
+
+	// MinInterval is the delay before the first poll, doubled after every
+	// poll. Defaults to 2 seconds.
+	MinInterval time.Duration
+
+	// MaxInterval caps the delay between polls. Defaults to 30 seconds.
+	MaxInterval time.Duration
+}
+
+// CreateStreamVideoDownload starts generating the MP4 download of a video.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-m-p-4-downloads-create-downloads
+func (api *API) CreateStreamVideoDownload(ctx context.Context, rc *ResourceContainer, videoID string) (StreamVideoDownloads, error) {
+	return api.streamVideoDownloads(ctx, rc, http.MethodPost, "CreateStreamVideoDownload", videoID)
+}
+
+// GetStreamVideoDownloads gets the downloads of a video.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-m-p-4-downloads-list-downloads
+func (api *API) GetStreamVideoDownloads(ctx context.Context, rc *ResourceContainer, videoID string) (StreamVideoDownloads, error) {
+	return api.streamVideoDownloads(ctx, rc, http.MethodGet, "GetStreamVideoDownloads", videoID)
+}
+
+func (api *API) streamVideoDownloads(ctx context.Context, rc *ResourceContainer, method, operation, videoID string) (StreamVideoDownloads, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamVideoDownloads{}, err
+	}
+
+	if videoID == "" {
+		return StreamVideoDownloads{}, ErrMissingVideoID
+	}
+
+	uri := fmt.Sprintf("%s/%s/downloads", streamBasePath(rc.Identifier), videoID)
+	res, err := api.makeRequestContext(ctx, method, uri, nil)
+	if method != http.MethodGet {
+		api.auditStream(ctx, StreamAuditEvent{Operation: operation, AccountID: rc.Identifier, VideoID: videoID}, err)
+	}
+	if err != nil {
+		return StreamVideoDownloads{}, wrapStreamTransportError(operation, uri, err)
+	}
+
+	var r StreamVideoDownloadsResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return StreamVideoDownloads{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	return r.Result, nil
+}
+
+// WaitForStreamVideoDownload polls the MP4 download of a video until it is
+// ready, backing off exponentially between polls. The download must have been
+// created using CreateStreamVideoDownload.
+func (api *API) WaitForStreamVideoDownload(ctx context.Context, rc *ResourceContainer, params WaitForStreamVideoDownloadParameters) (StreamVideoDownload, error) {
+	for attempt := 0; ; attempt++ {
+		downloads, err := api.GetStreamVideoDownloads(ctx, rc, params.VideoID)
+		if err != nil {
+			return StreamVideoDownload{}, err
+		}
+
+		download := downloads.Default
+		switch {
+		case download.Status == "error":
+			return download, ErrStreamVideoDownloadFailed
+		case download.Status == "ready", download.PercentComplete >= 100:
+			return download, nil
+		}
+
+		if err := sleepContext(ctx, streamPollInterval(attempt, params.MinInterval, params.MaxInterval)); err != nil {
+			return download, err
+		}
+	}
+}
+
+// streamPollInterval returns the delay before the poll following attempt,
+// doubling from min up to max. Zero values use the package defaults.
+func streamPollInterval(attempt int, min, max time.Duration) time.Duration {
+	if min <= 0 {
+		min = defaultStreamPollMinInterval
+	}
+	if max <= 0 {
+		max = defaultStreamPollMaxInterval
+	}
+
+	interval := min
+	for i := 0; i < attempt && interval < max; i++ {
+		interval *= 2
+	}
+
+	if interval > max {
+		return max
+	}
+	return interval
+}
+
+// sleepContext pauses for d or until ctx is done, returning the context error
+// in the latter case.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
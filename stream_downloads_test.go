@@ -0,0 +1,116 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream_CreateStreamVideoDownload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/downloads", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "default": {
+      "status": "inprogress",
+      "url": "https://customer-f33zs165nr7gyfy4.cloudflarestream.com/6b9e68b07dfee8cc2d116e4c51d6a957/downloads/default.mp4",
+      "percentComplete": 75.5
+    }
+  }
+}`)
+	})
+
+	_, err := client.CreateStreamVideoDownload(context.Background(), AccountIdentifier(""), testVideoID)
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	_, err = client.CreateStreamVideoDownload(context.Background(), AccountIdentifier(testAccountID), "")
+	assert.Equal(t, ErrMissingVideoID, err)
+
+	out, err := client.CreateStreamVideoDownload(context.Background(), AccountIdentifier(testAccountID), testVideoID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, StreamVideoDownloads{Default: StreamVideoDownload{
+			Status:          "inprogress",
+			URL:             "https://customer-f33zs165nr7gyfy4.cloudflarestream.com/6b9e68b07dfee8cc2d116e4c51d6a957/downloads/default.mp4",
+			PercentComplete: 75.5,
+		}}, out)
+	}
+}
+
+func TestStream_WaitForStreamVideoDownload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	progress := []float64{10, 50, 100}
+	polls := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/downloads", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+
+		status := "inprogress"
+		if progress[polls] == 100 {
+			status = "ready"
+		}
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"default": {"status": "%s", "url": "https://example.com/default.mp4", "percentComplete": %v}}}`, status, progress[polls])
+		polls++
+	})
+
+	out, err := client.WaitForStreamVideoDownload(context.Background(), AccountIdentifier(testAccountID), WaitForStreamVideoDownloadParameters{
+		VideoID:     testVideoID,
+		MinInterval: time.Millisecond,
+		MaxInterval: 2 * time.Millisecond,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ready", out.Status)
+		assert.Equal(t, float64(100), out.PercentComplete)
+		assert.Equal(t, 3, polls, "polling should stop once the download is complete")
+	}
+}
+
+func TestStream_WaitForStreamVideoDownload_ContextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/downloads", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"default": {"status": "inprogress", "percentComplete": 10}}}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.WaitForStreamVideoDownload(ctx, AccountIdentifier(testAccountID), WaitForStreamVideoDownloadParameters{
+		VideoID:     testVideoID,
+		MinInterval: time.Millisecond,
+	})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestStream_streamPollInterval(t *testing.T) {
+	var intervals []time.Duration
+	for attempt := 0; attempt < 6; attempt++ {
+		intervals = append(intervals, streamPollInterval(attempt, time.Second, 10*time.Second))
+	}
+	assert.Equal(t, []time.Duration{
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		10 * time.Second,
+		10 * time.Second,
+	}, intervals)
+
+	assert.Equal(t, defaultStreamPollMinInterval, streamPollInterval(0, 0, 0))
+	assert.Equal(t, defaultStreamPollMaxInterval, streamPollInterval(100, 0, 0))
+}
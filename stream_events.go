@@ -0,0 +1,131 @@
+package cloudflare
+
+import (
+	"context"
+	"time"
+)
+
+// StreamEvent is a change of the connection state of a live input detected
+// by StreamEvents.
+type StreamEvent struct {
+	LiveInputID   string
+	PreviousState string
+	State         string
+	DetectedAt    time.Time
+}
+
+// StreamEvents watches the live inputs of an account and emits an event
+// whenever the connection state of one changes. The Stream API has no event
+// feed so this polls every live input each interval; changes which revert
+// between two polls are not seen. The states found by the first poll are the
+// baseline and emit no events. A live input which has never connected has an
+// empty state, and one created after the first poll is compared against an
+// empty PreviousState, so its first connection emits an event. An interval of
+// 0 or less polls every 2 seconds.
+//
+// Errors encountered while polling are sent on the error channel and polling
+// continues. A live input which could not be fetched keeps its previous state
+// until it is fetched again, and its error is sent as StreamBulkErrors keyed
+// by live input UID. The error channel doesn't need to be read: an error is
+// dropped rather than holding up polling when the previous one has not been
+// received yet. Both channels are closed once ctx is done.
+func (api *API) StreamEvents(ctx context.Context, rc *ResourceContainer, interval time.Duration) (<-chan StreamEvent, <-chan error) {
+	if interval <= 0 {
+		interval = defaultStreamPollMinInterval
+	}
+
+	events := make(chan StreamEvent)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		var states map[string]string
+		// baseline holds the live inputs which could not be fetched by the
+		// first poll; their state once fetched is the baseline rather than a
+		// change.
+		baseline := map[string]bool{}
+		for {
+			current, err := api.streamLiveInputStates(ctx, rc)
+			if err != nil {
+				select {
+				case errs <- err:
+				default:
+				}
+			}
+
+			if current != nil {
+				if bulkErrs, ok := err.(StreamBulkErrors); ok {
+					for id := range bulkErrs {
+						if previous, ok := states[id]; ok {
+							current[id] = previous
+						} else if states == nil {
+							baseline[id] = true
+						}
+					}
+				}
+
+				if states != nil {
+					for id, state := range current {
+						previous, ok := states[id]
+						if !ok && baseline[id] {
+							delete(baseline, id)
+							continue
+						}
+						if previous == state {
+							continue
+						}
+
+						select {
+						case events <- StreamEvent{LiveInputID: id, PreviousState: previous, State: state, DetectedAt: time.Now()}:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+				states = current
+			}
+
+			if sleepContext(ctx, interval) != nil {
+				return
+			}
+		}
+	}()
+
+	return events, errs
+}
+
+// streamLiveInputStates returns the current connection state of every live
+// input of an account keyed by live input UID. Live inputs which could not be
+// fetched are left out and their errors are returned as StreamBulkErrors along
+// with the states of the others; the states are nil when the live inputs could
+// not be listed.
+func (api *API) streamLiveInputStates(ctx context.Context, rc *ResourceContainer) (map[string]string, error) {
+	inputs, err := api.ListStreamLiveInputs(ctx, rc, ListStreamLiveInputsParameters{})
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]string, len(inputs))
+	errs := StreamBulkErrors{}
+	for _, item := range inputs {
+		input, err := api.GetStreamLiveInput(ctx, rc, item.UID)
+		if err != nil {
+			errs[item.UID] = err
+			continue
+		}
+
+		// A live input which has never connected has no status.
+		var state string
+		if input.Status != nil {
+			state = input.Status.Current.State
+		}
+		states[item.UID] = state
+	}
+
+	if len(errs) > 0 {
+		return states, errs
+	}
+	return states, nil
+}
@@ -0,0 +1,204 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream_StreamEvents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"liveInputs": [{"uid": "%s"}], "range": 1000, "total": 1}}`, testLiveInputID)
+	})
+
+	var mu sync.Mutex
+	states := []string{"disconnected", "disconnected", "connected", "connected", "disconnected"}
+	polls := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		state := states[len(states)-1]
+		if polls < len(states) {
+			state = states[polls]
+		}
+		polls++
+		mu.Unlock()
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "status": {"current": {"state": "%s"}}}}`, testLiveInputID, state)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs := client.StreamEvents(ctx, AccountIdentifier(testAccountID), time.Millisecond)
+
+	var got []StreamEvent
+	for len(got) < 2 {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case err := <-errs:
+			t.Fatalf("unexpected error: %s", err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+
+	assert.Equal(t, testLiveInputID, got[0].LiveInputID)
+	assert.Equal(t, StreamLiveInputStateDisconnected, got[0].PreviousState)
+	assert.Equal(t, StreamLiveInputStateConnected, got[0].State)
+	assert.Equal(t, StreamLiveInputStateConnected, got[1].PreviousState)
+	assert.Equal(t, StreamLiveInputStateDisconnected, got[1].State)
+
+	cancel()
+	for range events {
+	}
+	_, open := <-errs
+	assert.False(t, open)
+}
+
+func TestStream_StreamEvents_FailedLiveInput(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const failingID = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"liveInputs": [{"uid": "%s"}, {"uid": "%s"}], "range": 1000, "total": 2}}`, testLiveInputID, failingID)
+	})
+
+	var mu sync.Mutex
+	polls := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		state := StreamLiveInputStateConnected
+		if polls == 0 {
+			state = StreamLiveInputStateDisconnected
+		}
+		polls++
+		mu.Unlock()
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "status": {"current": {"state": "%s"}}}}`, testLiveInputID, state)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+failingID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10003, "message": "live input not found"}], "messages": [], "result": null}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs := client.StreamEvents(ctx, AccountIdentifier(testAccountID), time.Millisecond)
+
+	// Only events are read; the errors of the failing live input must neither
+	// block polling nor hide the change of the other live input.
+	select {
+	case event := <-events:
+		assert.Equal(t, testLiveInputID, event.LiveInputID)
+		assert.Equal(t, StreamLiveInputStateConnected, event.State)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for events")
+	}
+
+	select {
+	case err := <-errs:
+		var bulkErrs StreamBulkErrors
+		require.True(t, errors.As(err, &bulkErrs))
+		assert.Contains(t, bulkErrs, failingID)
+		assert.NotContains(t, bulkErrs, testLiveInputID)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for errors")
+	}
+}
+
+func TestStream_StreamEvents_NeverConnected(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"liveInputs": [{"uid": "%s"}], "range": 1000, "total": 1}}`, testLiveInputID)
+	})
+
+	var mu sync.Mutex
+	polls := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		status := `{"current": {"state": "connected"}}`
+		if polls == 0 {
+			status = "null"
+		}
+		polls++
+		mu.Unlock()
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "status": %s}}`, testLiveInputID, status)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, _ := client.StreamEvents(ctx, AccountIdentifier(testAccountID), time.Millisecond)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, StreamEvent{LiveInputID: testLiveInputID, PreviousState: "", State: StreamLiveInputStateConnected, DetectedAt: event.DetectedAt}, event)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the first connection")
+	}
+}
+
+func TestStream_StreamEvents_NewLiveInput(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const newID = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	var mu sync.Mutex
+	lists := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inputs := fmt.Sprintf(`[{"uid": "%s"}, {"uid": "%s"}]`, testLiveInputID, newID)
+		if lists == 0 {
+			inputs = fmt.Sprintf(`[{"uid": "%s"}]`, testLiveInputID)
+		}
+		lists++
+		mu.Unlock()
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"liveInputs": %s, "range": 1000, "total": 2}}`, inputs)
+	})
+	for _, id := range []string{testLiveInputID, newID} {
+		id := id
+		mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+id, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "status": {"current": {"state": "connected"}}}}`, id)
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, _ := client.StreamEvents(ctx, AccountIdentifier(testAccountID), time.Millisecond)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, newID, event.LiveInputID, "only the live input added after the first poll changed")
+		assert.Empty(t, event.PreviousState)
+		assert.Equal(t, StreamLiveInputStateConnected, event.State)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the new live input")
+	}
+}
@@ -0,0 +1,1116 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// ErrMissingLiveInputID is for when LiveInputID is required but missing.
+	ErrMissingLiveInputID = errors.New("required live input id missing")
+	// ErrMissingLiveInputMetaKey is for when a meta key is required on the live input but missing.
+	ErrMissingLiveInputMetaKey = errors.New("required live input meta key missing")
+	// ErrConflictingLiveInputRecording is for when recording is disabled but recording settings are also provided.
+	ErrConflictingLiveInputRecording = errors.New("recording settings cannot be set when recording is disabled")
+	// ErrNoCurrentLiveInputRecording is for when a live input has no recording in progress.
+	ErrNoCurrentLiveInputRecording = errors.New("live input has no recording in progress")
+	// ErrInvalidLiveInputRecordingMode is for when a recording mode is not one of the known modes.
+	ErrInvalidLiveInputRecordingMode = errors.New("invalid live input recording mode")
+	// ErrLiveInputHasOutputs is for when turning recording off needs confirmation because the live input has outputs.
+	ErrLiveInputHasOutputs = errors.New("live input has outputs which keep simulcasting with recording off")
+	// ErrInvalidLiveInputRecordingTimeout is for when a recording timeout is negative or not a whole number of seconds.
+	ErrInvalidLiveInputRecordingTimeout = errors.New("recording timeout must be a non-negative whole number of seconds")
+	// ErrLiveInputRecordingsNotReady is for when a live input has recordings which are still being recorded or processed.
+	ErrLiveInputRecordingsNotReady = errors.New("live input has recordings which are not ready")
+)
+
+// StreamLiveInputRecordingMode controls whether broadcasts to a live input
+// are recorded.
+type StreamLiveInputRecordingMode string
+
+const (
+	StreamLiveInputRecordingModeOff       StreamLiveInputRecordingMode = "off"
+	StreamLiveInputRecordingModeAutomatic StreamLiveInputRecordingMode = "automatic"
+)
+
+// StreamVideoStateLiveInProgress is the state of the video recording a live
+// input while the broadcast is ongoing.
+const StreamVideoStateLiveInProgress = "live-inprogress"
+
+// StreamMetaMatch controls how meta values are compared when filtering.
+type StreamMetaMatch int
+
+const (
+	// StreamMetaMatchExact matches meta values equal to the filter value.
+	StreamMetaMatchExact StreamMetaMatch = iota
+	// StreamMetaMatchSubstring matches meta values containing the filter value.
+	StreamMetaMatchSubstring
+)
+
+// Connection states reported in a StreamLiveInputStatus.
+const (
+	StreamLiveInputStateConnected    = "connected"
+	StreamLiveInputStateDisconnected = "disconnected"
+)
+
+// StreamLiveInput represents a live input.
+type StreamLiveInput struct {
+	UID                      string                   `json:"uid,omitempty"`
+	Created                  *time.Time               `json:"created,omitempty"`
+	Modified                 *time.Time               `json:"modified,omitempty"`
+	Meta                     map[string]interface{}   `json:"meta,omitempty"`
+	DefaultCreator           string                   `json:"defaultCreator,omitempty"`
+	DeleteRecordingAfterDays int                      `json:"deleteRecordingAfterDays,omitempty"`
+	PreferLowLatency         bool                     `json:"preferLowLatency,omitempty"`
+	Recording                StreamLiveInputRecording `json:"recording,omitempty"`
+	RTMPS                    StreamLiveInputRTMPS     `json:"rtmps,omitempty"`
+	RTMPSPlayback            StreamLiveInputRTMPS     `json:"rtmpsPlayback,omitempty"`
+	SRT                      StreamLiveInputSRT       `json:"srt,omitempty"`
+	SRTPlayback              StreamLiveInputSRT       `json:"srtPlayback,omitempty"`
+	WebRTC                   StreamLiveInputWebRTC    `json:"webRTC,omitempty"`
+	WebRTCPlayback           StreamLiveInputWebRTC    `json:"webRTCPlayback,omitempty"`
+	Status                   *StreamLiveInputStatuses `json:"status,omitempty"`
+}
+
+// UnmarshalJSON decodes a live input, accepting timestamps in the layouts of
+// streamTimeLayouts. Timestamps in any other layout are left nil rather than
+// failing to decode the whole live input.
+func (l *StreamLiveInput) UnmarshalJSON(data []byte) error {
+	type Alias StreamLiveInput
+
+	aux := &struct {
+		Created  string `json:"created,omitempty"`
+		Modified string `json:"modified,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(l),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	l.Created = parseStreamTime(aux.Created)
+	l.Modified = parseStreamTime(aux.Modified)
+	return nil
+}
+
+// StreamLiveInputRecording represents the recording settings of a live input.
+//
+// RequireSignedURLs and AllowedOrigins are copied to the video of each
+// recording when it is created, so recordings can only be played with a
+// signed URL while RequireSignedURLs is set. Changing them later does not
+// update existing recordings, which have to be updated with UpdateStreamVideo.
+type StreamLiveInputRecording struct {
+	Mode                StreamLiveInputRecordingMode `json:"mode,omitempty"`
+	RequireSignedURLs   bool                         `json:"requireSignedURLs,omitempty"`
+	AllowedOrigins      []string                     `json:"allowedOrigins,omitempty"`
+	TimeoutSeconds      int                          `json:"timeoutSeconds,omitempty"`
+	HideLiveViewerCount bool                         `json:"hideLiveViewerCount,omitempty"`
+}
+
+// InheritsSignedURLs reports whether recordings made with these settings
+// require signed URLs. It is false unless recording is automatic, as the API
+// defaults to not recording and no videos are created to inherit the setting.
+func (r StreamLiveInputRecording) InheritsSignedURLs() bool {
+	return r.RequireSignedURLs && r.Mode == StreamLiveInputRecordingModeAutomatic
+}
+
+// WithTimeout returns a copy of the recording settings with TimeoutSeconds
+// set to timeout, the time to wait after a broadcast disconnects before its
+// recording ends. ErrInvalidLiveInputRecordingTimeout is returned when timeout
+// is negative or not a whole number of seconds. A timeout of zero uses the
+// default of the API.
+func (r StreamLiveInputRecording) WithTimeout(timeout time.Duration) (StreamLiveInputRecording, error) {
+	if timeout < 0 || timeout%time.Second != 0 {
+		return r, fmt.Errorf("%w: %s", ErrInvalidLiveInputRecordingTimeout, timeout)
+	}
+	r.TimeoutSeconds = int(timeout / time.Second)
+	return r, nil
+}
+
+// OriginAllowed reports whether playback of the live input's recordings is
+// allowed from origin. origin may be a hostname or a URL such as the value of
+// an Origin header. AllowedOrigins entries match hostnames exactly, or any
+// subdomain when prefixed with "*."; no entries allows every origin.
+func (l StreamLiveInput) OriginAllowed(origin string) bool {
+	if len(l.Recording.AllowedOrigins) == 0 {
+		return true
+	}
+
+	host := origin
+	if u, err := url.Parse(origin); err == nil && u.Host != "" {
+		host = u.Hostname()
+	}
+	host = strings.ToLower(host)
+
+	for _, allowed := range l.Recording.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		switch {
+		case allowed == "*", allowed == host:
+			return true
+		case strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:]):
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the "name" meta value of the live input, or an empty string
+// when it is absent or not a string.
+func (l StreamLiveInput) Name() string {
+	return streamMetaName(l.Meta)
+}
+
+// StreamLiveInputPlaybackURLs are the manifest URLs to play a live input
+// while it is broadcasting.
+type StreamLiveInputPlaybackURLs struct {
+	HLS  string
+	Dash string
+}
+
+// LivePlaybackURLs returns the manifest URLs to play the live input from
+// customerDomain, the customer subdomain of the account such as
+// "customer-f33zs165nr7gyfy4.cloudflarestream.com". The HLS manifest uses
+// low-latency HLS when the live input has PreferLowLatency set.
+func (l StreamLiveInput) LivePlaybackURLs(customerDomain string) StreamLiveInputPlaybackURLs {
+	base := fmt.Sprintf("https://%s/%s/manifest", customerDomain, l.UID)
+
+	hls := base + "/video.m3u8"
+	if l.PreferLowLatency {
+		hls += "?protocol=llhls"
+	}
+
+	return StreamLiveInputPlaybackURLs{
+		HLS:  hls,
+		Dash: base + "/video.mpd",
+	}
+}
+
+// StreamLiveInputRTMPS represents the RTMPS details of a live input.
+type StreamLiveInputRTMPS struct {
+	URL       string `json:"url,omitempty"`
+	StreamKey string `json:"streamKey,omitempty"`
+}
+
+// RTMPSIngestURL returns the RTMPS URL, including the stream key, that
+// broadcasting software pushes to in order to go live on the live input. It is
+// empty if the live input has no RTMPS ingest details.
+func (l StreamLiveInput) RTMPSIngestURL() string {
+	return l.RTMPS.fullURL()
+}
+
+// RTMPSPlaybackURL returns the RTMPS URL, including the playback key, that
+// viewers or restreaming tools pull the live broadcast from. Unlike the ingest
+// URL it can't be used to broadcast. It is empty if the live input has no
+// RTMPS playback details.
+func (l StreamLiveInput) RTMPSPlaybackURL() string {
+	return l.RTMPSPlayback.fullURL()
+}
+
+// fullURL joins the URL and stream key into a single URL.
+func (r StreamLiveInputRTMPS) fullURL() string {
+	if r.URL == "" {
+		return ""
+	}
+	if r.StreamKey == "" {
+		return r.URL
+	}
+	return strings.TrimSuffix(r.URL, "/") + "/" + r.StreamKey
+}
+
+// StreamLiveInputSRT represents the SRT details of a live input.
+type StreamLiveInputSRT struct {
+	URL        string `json:"url,omitempty"`
+	StreamID   string `json:"streamId,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// StreamLiveInputWebRTC represents the WebRTC details of a live input.
+type StreamLiveInputWebRTC struct {
+	URL string `json:"url,omitempty"`
+}
+
+// StreamLiveInputProtocol is a protocol a live input can be broadcast to or
+// played back from.
+type StreamLiveInputProtocol string
+
+const (
+	StreamLiveInputProtocolRTMPS  StreamLiveInputProtocol = "rtmps"
+	StreamLiveInputProtocolSRT    StreamLiveInputProtocol = "srt"
+	StreamLiveInputProtocolWebRTC StreamLiveInputProtocol = "webrtc"
+)
+
+// StreamLiveInputEndpoint is an ingest or playback endpoint of a live input.
+type StreamLiveInputEndpoint struct {
+	Protocol StreamLiveInputProtocol
+	URL      string
+	// StreamKey is the RTMPS stream key or the SRT stream ID, and is empty
+	// for WebRTC.
+	StreamKey string
+	// Passphrase is only set for SRT.
+	Passphrase string
+}
+
+// StreamLiveInputEndpoints are the ingest and playback endpoints of a live
+// input, ordered RTMPS, SRT and then WebRTC.
+type StreamLiveInputEndpoints struct {
+	Ingest   []StreamLiveInputEndpoint
+	Playback []StreamLiveInputEndpoint
+}
+
+// IngestEndpoints returns the ingest and playback endpoints of every protocol
+// of the live input in a single form. Protocols without a URL are left out.
+func (l StreamLiveInput) IngestEndpoints() StreamLiveInputEndpoints {
+	return StreamLiveInputEndpoints{
+		Ingest:   streamLiveInputEndpoints(l.RTMPS, l.SRT, l.WebRTC),
+		Playback: streamLiveInputEndpoints(l.RTMPSPlayback, l.SRTPlayback, l.WebRTCPlayback),
+	}
+}
+
+func streamLiveInputEndpoints(rtmps StreamLiveInputRTMPS, srt StreamLiveInputSRT, webRTC StreamLiveInputWebRTC) []StreamLiveInputEndpoint {
+	var endpoints []StreamLiveInputEndpoint
+	if rtmps.URL != "" {
+		endpoints = append(endpoints, StreamLiveInputEndpoint{Protocol: StreamLiveInputProtocolRTMPS, URL: rtmps.URL, StreamKey: rtmps.StreamKey})
+	}
+	if srt.URL != "" {
+		endpoints = append(endpoints, StreamLiveInputEndpoint{Protocol: StreamLiveInputProtocolSRT, URL: srt.URL, StreamKey: srt.StreamID, Passphrase: srt.Passphrase})
+	}
+	if webRTC.URL != "" {
+		endpoints = append(endpoints, StreamLiveInputEndpoint{Protocol: StreamLiveInputProtocolWebRTC, URL: webRTC.URL})
+	}
+	return endpoints
+}
+
+// StreamLiveInputStatuses represents the current and previous connection
+// statuses of a live input.
+type StreamLiveInputStatuses struct {
+	Current StreamLiveInputStatus   `json:"current,omitempty"`
+	History []StreamLiveInputStatus `json:"history,omitempty"`
+}
+
+// Transitions returns the history and current statuses ordered by the time
+// they were entered, oldest first, ending with the current status. History
+// entries without a StatusEnteredAt are placed first. The current status is
+// omitted when it has no state or is already the last history entry.
+func (s StreamLiveInputStatuses) Transitions() []StreamLiveInputStatus {
+	transitions := make([]StreamLiveInputStatus, len(s.History), len(s.History)+1)
+	copy(transitions, s.History)
+	sort.SliceStable(transitions, func(i, j int) bool {
+		a, b := transitions[i].StatusEnteredAt, transitions[j].StatusEnteredAt
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.Before(*b)
+	})
+
+	if s.Current.State == "" {
+		return transitions
+	}
+	if n := len(transitions); n > 0 && sameStreamLiveInputStatus(transitions[n-1], s.Current) {
+		return transitions
+	}
+	return append(transitions, s.Current)
+}
+
+// LastConnectedAt returns the time the live input last entered the connected
+// state, or nil when it has never been seen connected.
+func (s StreamLiveInputStatuses) LastConnectedAt() *time.Time {
+	var last *time.Time
+	for _, status := range append([]StreamLiveInputStatus{s.Current}, s.History...) {
+		if status.State != StreamLiveInputStateConnected || status.StatusEnteredAt == nil {
+			continue
+		}
+		if last == nil || status.StatusEnteredAt.After(*last) {
+			last = status.StatusEnteredAt
+		}
+	}
+	return last
+}
+
+// sameStreamLiveInputStatus reports whether a and b are the same state
+// entered at the same time.
+func sameStreamLiveInputStatus(a, b StreamLiveInputStatus) bool {
+	if a.State != b.State || (a.StatusEnteredAt == nil) != (b.StatusEnteredAt == nil) {
+		return false
+	}
+	return a.StatusEnteredAt == nil || a.StatusEnteredAt.Equal(*b.StatusEnteredAt)
+}
+
+// StreamLiveInputStatus represents a single connection status of a live input.
+type StreamLiveInputStatus struct {
+	State           string     `json:"state,omitempty"`
+	Reason          string     `json:"reason,omitempty"`
+	IngestProtocol  string     `json:"ingestProtocol,omitempty"`
+	StatusEnteredAt *time.Time `json:"statusEnteredAt,omitempty"`
+	StatusLastSeen  *time.Time `json:"statusLastSeen,omitempty"`
+}
+
+// IsStatusStale reports whether the status was last seen more than maxAge
+// ago, which may indicate that monitoring of the live input was lost. A
+// status without a StatusLastSeen timestamp is considered stale.
+func (s StreamLiveInputStatus) IsStatusStale(maxAge time.Duration) bool {
+	return s.isStatusStaleAt(time.Now(), maxAge)
+}
+
+func (s StreamLiveInputStatus) isStatusStaleAt(now time.Time, maxAge time.Duration) bool {
+	if s.StatusLastSeen == nil {
+		return true
+	}
+	return now.Sub(*s.StatusLastSeen) > maxAge
+}
+
+// StreamLiveInputListItem represents a live input as returned when listing
+// live inputs. Recording and ScheduledDeletion are only populated when the
+// list endpoint includes them in the response. DeleteRecordingAfterDays is
+// nil when the live input has no retention policy.
+type StreamLiveInputListItem struct {
+	UID                      string                   `json:"uid,omitempty"`
+	Created                  *time.Time               `json:"created,omitempty"`
+	Modified                 *time.Time               `json:"modified,omitempty"`
+	Meta                     map[string]interface{}   `json:"meta,omitempty"`
+	DeleteRecordingAfterDays *int                     `json:"deleteRecordingAfterDays,omitempty"`
+	Recording                StreamLiveInputRecording `json:"recording,omitempty"`
+	ScheduledDeletion        *time.Time               `json:"scheduledDeletion,omitempty"`
+}
+
+// Name returns the "name" meta value of the live input, or an empty string
+// when it is absent or not a string.
+func (l StreamLiveInputListItem) Name() string {
+	return streamMetaName(l.Meta)
+}
+
+// streamMetaName returns the "name" value of meta if it is a string.
+func streamMetaName(meta map[string]interface{}) string {
+	name, _ := meta["name"].(string)
+	return name
+}
+
+// CreateStreamLiveInputParameters are the parameters used when creating a
+// live input.
+//
+// A nil Recording omits the recording settings from the request, while a
+// pointer to a zero StreamLiveInputRecording sends an empty recording
+// object.
+type CreateStreamLiveInputParameters struct {
+	DefaultCreator           string                    `json:"defaultCreator,omitempty"`
+	DeleteRecordingAfterDays int                       `json:"deleteRecordingAfterDays,omitempty"`
+	PreferLowLatency         bool                      `json:"preferLowLatency,omitempty"`
+	Meta                     map[string]interface{}    `json:"meta,omitempty"`
+	Recording                *StreamLiveInputRecording `json:"recording,omitempty"`
+
+	// DisableRecording creates the live input with recording mode "off".
+	// Setting a recording mode other than "off", RequireSignedURLs or
+	// AllowedOrigins alongside it is rejected as those only apply to
+	// recordings.
+	DisableRecording bool `json:"-"`
+}
+
+// validate reports parameters that contradict each other.
+func (p CreateStreamLiveInputParameters) validate() error {
+	if p.DisableRecording && p.Recording != nil {
+		mode := p.Recording.Mode
+		if (mode != "" && mode != StreamLiveInputRecordingModeOff) || p.Recording.RequireSignedURLs || len(p.Recording.AllowedOrigins) > 0 {
+			return ErrConflictingLiveInputRecording
+		}
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the parameters with every unset value
+// replaced by the one from defaults. Meta keys are merged with the keys of
+// the parameters taking precedence.
+//
+// Boolean recording settings can only be enabled by defaults; as false is
+// indistinguishable from unset, a default of true cannot be overridden with
+// false. PreferLowLatency and DisableRecording are not defaulted.
+func (p CreateStreamLiveInputParameters) withDefaults(defaults CreateStreamLiveInputParameters) CreateStreamLiveInputParameters {
+	if p.DefaultCreator == "" {
+		p.DefaultCreator = defaults.DefaultCreator
+	}
+
+	if p.DeleteRecordingAfterDays == 0 {
+		p.DeleteRecordingAfterDays = defaults.DeleteRecordingAfterDays
+	}
+
+	if len(defaults.Meta) > 0 {
+		meta := make(map[string]interface{}, len(defaults.Meta)+len(p.Meta))
+		for k, v := range defaults.Meta {
+			meta[k] = v
+		}
+		for k, v := range p.Meta {
+			meta[k] = v
+		}
+		p.Meta = meta
+	}
+
+	if defaults.Recording != nil {
+		var recording StreamLiveInputRecording
+		if p.Recording != nil {
+			recording = *p.Recording
+		}
+
+		if recording.Mode == "" {
+			recording.Mode = defaults.Recording.Mode
+		}
+		if defaults.Recording.RequireSignedURLs {
+			recording.RequireSignedURLs = true
+		}
+		if recording.AllowedOrigins == nil {
+			recording.AllowedOrigins = defaults.Recording.AllowedOrigins
+		}
+		if recording.TimeoutSeconds == 0 {
+			recording.TimeoutSeconds = defaults.Recording.TimeoutSeconds
+		}
+		if defaults.Recording.HideLiveViewerCount {
+			recording.HideLiveViewerCount = true
+		}
+		p.Recording = &recording
+	}
+
+	return p
+}
+
+// UpdateStreamLiveInputParameters are the parameters used when updating a
+// live input. As when creating, a nil Recording is omitted and a pointer to
+// a zero StreamLiveInputRecording sends an empty recording object.
+//
+// DefaultCreator is left unchanged when nil and cleared when pointing to an
+// empty string.
+type UpdateStreamLiveInputParameters struct {
+	LiveInputID              string                    `json:"-"`
+	DefaultCreator           *string                   `json:"defaultCreator,omitempty"`
+	DeleteRecordingAfterDays int                       `json:"deleteRecordingAfterDays,omitempty"`
+	PreferLowLatency         bool                      `json:"preferLowLatency,omitempty"`
+	Meta                     map[string]interface{}    `json:"meta,omitempty"`
+	Recording                *StreamLiveInputRecording `json:"recording,omitempty"`
+}
+
+// SetStreamLiveInputRecordingModeParameters are the parameters used when
+// setting the recording mode of a live input.
+type SetStreamLiveInputRecordingModeParameters struct {
+	LiveInputID string
+	Mode        StreamLiveInputRecordingMode
+
+	// RequireConfirmation checks for outputs before turning recording off and
+	// returns ErrLiveInputHasOutputs without updating the live input when
+	// there are any, as they keep simulcasting broadcasts which are no longer
+	// recorded. Set it to false to confirm once the caller has been warned.
+	RequireConfirmation bool
+}
+
+// ListStreamLiveInputsParameters are the parameters used when listing live
+// inputs.
+type ListStreamLiveInputsParameters struct {
+	// IncludeCounts asks the API to report the range and total number of live
+	// inputs in StreamLiveInputListResponse. The API does not report how many
+	// of them are currently connected; use GetStreamLiveInput for the status
+	// of an individual input.
+	IncludeCounts bool `url:"include_counts,omitempty"`
+
+	// ModifiedSince asks the API to only return live inputs modified after
+	// the given time, for incremental syncs. It is sent as the
+	// modified_since query parameter and the results are not filtered
+	// further.
+	ModifiedSince *time.Time `url:"modified_since,omitempty"`
+}
+
+// StreamLiveInputResponse represents an API response of a live input.
+type StreamLiveInputResponse struct {
+	Response
+	Result StreamLiveInput `json:"result,omitempty"`
+}
+
+// StreamLiveInputListResponse represents an API response of listing live
+// inputs.
+type StreamLiveInputListResponse struct {
+	Response
+	Result struct {
+		LiveInputs []StreamLiveInputListItem `json:"liveInputs,omitempty"`
+		Range      int                       `json:"range,omitempty"`
+		Total      int                       `json:"total,omitempty"`
+	} `json:"result,omitempty"`
+}
+
+// StreamLiveInputOBSSettings are the values needed to configure OBS (or any
+// other RTMPS broadcasting software) to stream to a live input.
+type StreamLiveInputOBSSettings struct {
+	LiveInputID string
+	Server      string
+	StreamKey   string
+}
+
+// CreateStreamLiveInput creates a live input.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-live-inputs-create-a-live-input
+func (api *API) CreateStreamLiveInput(ctx context.Context, rc *ResourceContainer, params CreateStreamLiveInputParameters) (StreamLiveInput, error) {
+	input, _, err := api.CreateStreamLiveInputWithResponse(ctx, rc, params)
+	return input, err
+}
+
+// CreateStreamLiveInputWithResponse creates a live input like
+// CreateStreamLiveInput and also returns the status code, headers and
+// messages of the response.
+func (api *API) CreateStreamLiveInputWithResponse(ctx context.Context, rc *ResourceContainer, params CreateStreamLiveInputParameters) (StreamLiveInput, ResponseMetadata, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamLiveInput{}, ResponseMetadata{}, err
+	}
+
+	if err := params.validate(); err != nil {
+		return StreamLiveInput{}, ResponseMetadata{}, err
+	}
+
+	if api.streamLiveInputDefaults != nil {
+		params = params.withDefaults(*api.streamLiveInputDefaults)
+	}
+
+	if params.DisableRecording {
+		var recording StreamLiveInputRecording
+		if params.Recording != nil {
+			recording = *params.Recording
+		}
+		recording.Mode = StreamLiveInputRecordingModeOff
+		params.Recording = &recording
+	}
+
+	meta, err := normalizeStreamMeta(params.Meta)
+	if err != nil {
+		return StreamLiveInput{}, ResponseMetadata{}, err
+	}
+	params.Meta = meta
+
+	var warning error
+	if params.Recording != nil {
+		warning = api.checkStreamAllowedOrigins("CreateStreamLiveInput", params.Recording.AllowedOrigins, params.Recording.RequireSignedURLs)
+	}
+
+	uri := streamBasePath(rc.Identifier) + "/live_inputs"
+	res, err := api.makeRequestContextWithHeadersComplete(ctx, http.MethodPost, uri, params, nil)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "CreateStreamLiveInput", AccountID: rc.Identifier}, err)
+	if err != nil {
+		return StreamLiveInput{}, ResponseMetadata{}, wrapStreamTransportError("CreateStreamLiveInput", uri, err)
+	}
+
+	metadata := ResponseMetadata{StatusCode: res.StatusCode, Headers: res.Headers}
+
+	var r StreamLiveInputResponse
+	if err := json.Unmarshal(res.Body, &r); err != nil {
+		return StreamLiveInput{}, metadata, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	metadata.Messages = r.Messages
+	return r.Result, metadata, warning
+}
+
+// CreateStreamLiveInputForOBS creates a live input and returns the RTMPS
+// server URL and stream key to configure in OBS.
+func (api *API) CreateStreamLiveInputForOBS(ctx context.Context, rc *ResourceContainer, params CreateStreamLiveInputParameters) (StreamLiveInputOBSSettings, error) {
+	input, err := api.CreateStreamLiveInput(ctx, rc, params)
+	if err != nil && !errors.Is(err, ErrAllowedOriginsWithoutSignedURLs) {
+		return StreamLiveInputOBSSettings{}, err
+	}
+
+	return StreamLiveInputOBSSettings{
+		LiveInputID: input.UID,
+		Server:      input.RTMPS.URL,
+		StreamKey:   input.RTMPS.StreamKey,
+	}, err
+}
+
+// CreateStreamLiveInputIfAbsent creates a live input unless one already exists
+// with the same value for the uniqueMetaKey meta field. The existing live
+// input is returned if found, otherwise the newly created one. The returned
+// boolean reports whether a live input was created.
+//
+// The lookup and creation are not atomic; concurrent calls using the same
+// meta value may still create duplicates.
+func (api *API) CreateStreamLiveInputIfAbsent(ctx context.Context, rc *ResourceContainer, params CreateStreamLiveInputParameters, uniqueMetaKey string) (StreamLiveInput, bool, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamLiveInput{}, false, err
+	}
+
+	value, ok := params.Meta[uniqueMetaKey]
+	if uniqueMetaKey == "" || !ok {
+		return StreamLiveInput{}, false, ErrMissingLiveInputMetaKey
+	}
+
+	inputs, err := api.ListStreamLiveInputs(ctx, rc, ListStreamLiveInputsParameters{})
+	if err != nil {
+		return StreamLiveInput{}, false, err
+	}
+
+	for _, input := range inputs {
+		existing, ok := input.Meta[uniqueMetaKey]
+		if ok && fmt.Sprint(existing) == fmt.Sprint(value) {
+			liveInput, err := api.GetStreamLiveInput(ctx, rc, input.UID)
+			return liveInput, false, err
+		}
+	}
+
+	liveInput, err := api.CreateStreamLiveInput(ctx, rc, params)
+	if err != nil && !errors.Is(err, ErrAllowedOriginsWithoutSignedURLs) {
+		return StreamLiveInput{}, false, err
+	}
+	return liveInput, true, err
+}
+
+// GetStreamLiveInput gets the details of a live input. The API can report
+// success with an empty result; see UsingEmptyResultErrors to treat that as an
+// error.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-live-inputs-retrieve-a-live-input
+func (api *API) GetStreamLiveInput(ctx context.Context, rc *ResourceContainer, liveInputID string) (StreamLiveInput, error) {
+	input, _, err := api.GetStreamLiveInputWithResponse(ctx, rc, liveInputID)
+	return input, err
+}
+
+// GetStreamLiveInputWithResponse gets a live input like GetStreamLiveInput
+// and also returns the status code, headers and messages of the response.
+func (api *API) GetStreamLiveInputWithResponse(ctx context.Context, rc *ResourceContainer, liveInputID string) (StreamLiveInput, ResponseMetadata, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamLiveInput{}, ResponseMetadata{}, err
+	}
+
+	if liveInputID == "" {
+		return StreamLiveInput{}, ResponseMetadata{}, ErrMissingLiveInputID
+	}
+
+	uri := fmt.Sprintf("%s/live_inputs/%s", streamBasePath(rc.Identifier), liveInputID)
+	res, err := api.makeRequestContextWithHeadersComplete(ctx, http.MethodGet, uri, nil, nil)
+	if err != nil {
+		return StreamLiveInput{}, ResponseMetadata{}, wrapStreamTransportError("GetStreamLiveInput", uri, err)
+	}
+
+	metadata := ResponseMetadata{StatusCode: res.StatusCode, Headers: res.Headers}
+
+	var r StreamLiveInputResponse
+	if err := json.Unmarshal(res.Body, &r); err != nil {
+		return StreamLiveInput{}, metadata, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	metadata.Messages = r.Messages
+
+	if api.emptyResultErrors && r.Result.UID == "" {
+		return StreamLiveInput{}, metadata, fmt.Errorf("%w: GetStreamLiveInput %s", ErrEmptyResult, liveInputID)
+	}
+	return r.Result, metadata, nil
+}
+
+// ExistsStreamLiveInput reports whether a live input exists. A not found
+// response is reported as false without an error; any other failure is
+// returned as the error.
+func (api *API) ExistsStreamLiveInput(ctx context.Context, rc *ResourceContainer, liveInputID string) (bool, error) {
+	_, err := api.GetStreamLiveInput(ctx, rc, liveInputID)
+	if err != nil {
+		var notFoundError *NotFoundError
+		if errors.As(err, &notFoundError) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ListStreamLiveInputs lists the live inputs of an account.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-live-inputs-list-live-inputs
+func (api *API) ListStreamLiveInputs(ctx context.Context, rc *ResourceContainer, params ListStreamLiveInputsParameters) ([]StreamLiveInputListItem, error) {
+	inputs, _, err := api.ListStreamLiveInputsWithResponse(ctx, rc, params)
+	return inputs, err
+}
+
+// ListStreamLiveInputsWithResponse lists live inputs like ListStreamLiveInputs
+// and also returns the status code, headers and messages of the response.
+func (api *API) ListStreamLiveInputsWithResponse(ctx context.Context, rc *ResourceContainer, params ListStreamLiveInputsParameters) ([]StreamLiveInputListItem, ResponseMetadata, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return []StreamLiveInputListItem{}, ResponseMetadata{}, err
+	}
+
+	uri := buildURI(streamBasePath(rc.Identifier)+"/live_inputs", params)
+	res, err := api.makeRequestContextWithHeadersComplete(ctx, http.MethodGet, uri, nil, nil)
+	if err != nil {
+		return []StreamLiveInputListItem{}, ResponseMetadata{}, wrapStreamTransportError("ListStreamLiveInputs", uri, err)
+	}
+
+	metadata := ResponseMetadata{StatusCode: res.StatusCode, Headers: res.Headers}
+
+	var r StreamLiveInputListResponse
+	if err := json.Unmarshal(res.Body, &r); err != nil {
+		return []StreamLiveInputListItem{}, metadata, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	metadata.Messages = r.Messages
+	return r.Result.LiveInputs, metadata, nil
+}
+
+// UpdateStreamLiveInput updates a live input.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-live-inputs-update-a-live-input
+func (api *API) UpdateStreamLiveInput(ctx context.Context, rc *ResourceContainer, params UpdateStreamLiveInputParameters) (StreamLiveInput, error) {
+	input, _, err := api.UpdateStreamLiveInputWithResponse(ctx, rc, params)
+	return input, err
+}
+
+// UpdateStreamLiveInputWithResponse updates a live input like
+// UpdateStreamLiveInput and also returns the status code, headers and
+// messages of the response.
+func (api *API) UpdateStreamLiveInputWithResponse(ctx context.Context, rc *ResourceContainer, params UpdateStreamLiveInputParameters) (StreamLiveInput, ResponseMetadata, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamLiveInput{}, ResponseMetadata{}, err
+	}
+
+	if params.LiveInputID == "" {
+		return StreamLiveInput{}, ResponseMetadata{}, ErrMissingLiveInputID
+	}
+
+	meta, err := normalizeStreamMeta(params.Meta)
+	if err != nil {
+		return StreamLiveInput{}, ResponseMetadata{}, err
+	}
+	params.Meta = meta
+
+	var warning error
+	if params.Recording != nil {
+		warning = api.checkStreamAllowedOrigins("UpdateStreamLiveInput", params.Recording.AllowedOrigins, params.Recording.RequireSignedURLs)
+	}
+
+	uri := fmt.Sprintf("%s/live_inputs/%s", streamBasePath(rc.Identifier), params.LiveInputID)
+	res, err := api.makeRequestContextWithHeadersComplete(ctx, http.MethodPut, uri, params, nil)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "UpdateStreamLiveInput", AccountID: rc.Identifier, LiveInputID: params.LiveInputID}, err)
+	if err != nil {
+		return StreamLiveInput{}, ResponseMetadata{}, wrapStreamTransportError("UpdateStreamLiveInput", uri, err)
+	}
+
+	metadata := ResponseMetadata{StatusCode: res.StatusCode, Headers: res.Headers}
+
+	var r StreamLiveInputResponse
+	if err := json.Unmarshal(res.Body, &r); err != nil {
+		return StreamLiveInput{}, metadata, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	metadata.Messages = r.Messages
+	return r.Result, metadata, warning
+}
+
+// SetStreamLiveInputRecordingMode sets the recording mode of a live input,
+// keeping its other recording settings.
+func (api *API) SetStreamLiveInputRecordingMode(ctx context.Context, rc *ResourceContainer, params SetStreamLiveInputRecordingModeParameters) (StreamLiveInput, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamLiveInput{}, err
+	}
+
+	if params.LiveInputID == "" {
+		return StreamLiveInput{}, ErrMissingLiveInputID
+	}
+
+	if params.Mode != StreamLiveInputRecordingModeOff && params.Mode != StreamLiveInputRecordingModeAutomatic {
+		return StreamLiveInput{}, fmt.Errorf("%w: %q", ErrInvalidLiveInputRecordingMode, params.Mode)
+	}
+
+	if params.Mode == StreamLiveInputRecordingModeOff && params.RequireConfirmation {
+		outputs, err := api.ListStreamLiveInputOutputs(ctx, rc, params.LiveInputID)
+		if err != nil {
+			return StreamLiveInput{}, err
+		}
+		if len(outputs) > 0 {
+			return StreamLiveInput{}, fmt.Errorf("%w: %d output(s)", ErrLiveInputHasOutputs, len(outputs))
+		}
+	}
+
+	input, err := api.GetStreamLiveInput(ctx, rc, params.LiveInputID)
+	if err != nil {
+		return StreamLiveInput{}, err
+	}
+
+	recording := input.Recording
+	recording.Mode = params.Mode
+	return api.UpdateStreamLiveInput(ctx, rc, UpdateStreamLiveInputParameters{
+		LiveInputID: params.LiveInputID,
+		Recording:   &recording,
+	})
+}
+
+// DeleteStreamLiveInput deletes a live input. See
+// UsingIdempotentStreamLiveInputDeletes for treating a live input deleted by
+// an earlier attempt of a retried request as deleted.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-live-inputs-delete-a-live-input
+func (api *API) DeleteStreamLiveInput(ctx context.Context, rc *ResourceContainer, liveInputID string) error {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return err
+	}
+
+	if liveInputID == "" {
+		return ErrMissingLiveInputID
+	}
+
+	uri := fmt.Sprintf("%s/live_inputs/%s", streamBasePath(rc.Identifier), liveInputID)
+	_, err := api.makeRequestContext(ctx, http.MethodDelete, uri, nil)
+	if api.idempotentStreamLiveInputDeletes && isRetriedNotFound(err) {
+		err = nil
+	}
+	api.auditStream(ctx, StreamAuditEvent{Operation: "DeleteStreamLiveInput", AccountID: rc.Identifier, LiveInputID: liveInputID}, err)
+	if err != nil {
+		return wrapStreamTransportError("DeleteStreamLiveInput", uri, err)
+	}
+	return nil
+}
+
+// isRetriedNotFound reports whether err is a not found response received
+// after the request was retried.
+func isRetriedNotFound(err error) bool {
+	var notFoundError *NotFoundError
+	return errors.As(err, &notFoundError) && notFoundError.cloudflareError.Attempts > 1
+}
+
+// ListStreamLiveInputVideos lists the videos recorded from a live input.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-live-inputs-list-videos-associated-with-a-live-input
+func (api *API) ListStreamLiveInputVideos(ctx context.Context, rc *ResourceContainer, liveInputID string) ([]StreamVideo, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return []StreamVideo{}, err
+	}
+
+	if liveInputID == "" {
+		return []StreamVideo{}, ErrMissingLiveInputID
+	}
+
+	uri := fmt.Sprintf("%s/live_inputs/%s/videos", streamBasePath(rc.Identifier), liveInputID)
+	res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return []StreamVideo{}, wrapStreamTransportError("ListStreamLiveInputVideos", uri, err)
+	}
+
+	var r StreamListResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return []StreamVideo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	return r.Result, nil
+}
+
+// DeleteStreamLiveInputVideos deletes the videos recorded from a live input
+// concurrently. The recording of an ongoing broadcast is left alone. The
+// errors of videos which could not be deleted are returned as
+// StreamBulkErrors keyed by video UID.
+func (api *API) DeleteStreamLiveInputVideos(ctx context.Context, rc *ResourceContainer, liveInputID string, opts ...StreamBulkOption) error {
+	videos, err := api.ListStreamLiveInputVideos(ctx, rc, liveInputID)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(videos))
+	for _, video := range videos {
+		if video.Status.State != StreamVideoStateLiveInProgress {
+			ids = append(ids, video.UID)
+		}
+	}
+
+	op := func(id string) StreamBulkOperation {
+		return StreamBulkOperation{Method: http.MethodDelete, URI: fmt.Sprintf("%s/%s", streamBasePath(rc.Identifier), id), TargetID: id}
+	}
+	return streamBulkChange(ctx, ids, op, func(ctx context.Context, id string) error {
+		return api.StreamDeleteVideo(ctx, StreamParameters{AccountID: rc.Identifier, VideoID: id})
+	}, opts...)
+}
+
+// FilterStreamLiveInputsByMeta returns the live inputs whose meta value for
+// key matches value. Meta values which aren't strings are compared using
+// their fmt.Sprint representation; live inputs without the key never match.
+func FilterStreamLiveInputsByMeta(items []StreamLiveInputListItem, key, value string, match StreamMetaMatch) []StreamLiveInputListItem {
+	var filtered []StreamLiveInputListItem
+	for _, item := range items {
+		v, ok := item.Meta[key]
+		if !ok {
+			continue
+		}
+
+		s := fmt.Sprint(v)
+		if (match == StreamMetaMatchExact && s == value) || (match == StreamMetaMatchSubstring && strings.Contains(s, value)) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// StreamLiveInputSummary is a lightweight projection of a live input, holding
+// its identity and a subset of its meta.
+type StreamLiveInputSummary struct {
+	UID      string
+	Modified *time.Time
+	Meta     map[string]interface{}
+}
+
+// ProjectStreamLiveInputs returns summaries of the live inputs keeping only
+// the given meta keys, so that large listings can be retained without their
+// full meta and recording settings. Meta is nil when none of the keys are
+// present.
+//
+// The API does not support selecting fields when listing live inputs, so the
+// projection happens client side and does not reduce the size of the
+// response itself.
+func ProjectStreamLiveInputs(items []StreamLiveInputListItem, metaKeys ...string) []StreamLiveInputSummary {
+	summaries := make([]StreamLiveInputSummary, 0, len(items))
+	for _, item := range items {
+		summary := StreamLiveInputSummary{UID: item.UID, Modified: item.Modified}
+		for _, key := range metaKeys {
+			v, ok := item.Meta[key]
+			if !ok {
+				continue
+			}
+			if summary.Meta == nil {
+				summary.Meta = make(map[string]interface{}, len(metaKeys))
+			}
+			summary.Meta[key] = v
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// GetStreamLiveInputViewers returns the number of viewers currently watching a
+// live input, from customerDomain, the customer subdomain of the account such
+// as "customer-f33zs165nr7gyfy4.cloudflarestream.com". The count is zero when
+// the live input is not broadcasting.
+//
+// The count is served by the customer subdomain rather than the API, so the
+// request is not authenticated and does not count towards the rate limit.
+//
+// API Reference: https://developers.cloudflare.com/stream/stream-live/watch-live-stream/#live-viewer-count-third-party-players
+func (api *API) GetStreamLiveInputViewers(ctx context.Context, customerDomain, liveInputID string) (int, error) {
+	if liveInputID == "" {
+		return 0, ErrMissingLiveInputID
+	}
+
+	base := customerDomain
+	if !strings.Contains(base, "://") {
+		base = "https://" + base
+	}
+	uri := fmt.Sprintf("%s/%s/views", base, liveInputID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return 0, fmt.Errorf("HTTP request creation failed: %w", err)
+	}
+	if api.UserAgent != "" {
+		req.Header.Set("User-Agent", api.UserAgent)
+	}
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return 0, wrapStreamTransportError("GetStreamLiveInputViewers", uri, fmt.Errorf("HTTP request failed: %w", err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("could not read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidStatusCode, resp.StatusCode)
+	}
+
+	var r struct {
+		LiveViewers int `json:"liveViewers"`
+	}
+	if err := json.Unmarshal(body, &r); err != nil {
+		return 0, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	return r.LiveViewers, nil
+}
+
+// GetCurrentStreamLiveInputRecording returns the video being recorded from
+// an ongoing broadcast to a live input. ErrNoCurrentLiveInputRecording is
+// returned when the live input is not being recorded.
+func (api *API) GetCurrentStreamLiveInputRecording(ctx context.Context, rc *ResourceContainer, liveInputID string) (StreamVideo, error) {
+	videos, err := api.ListStreamLiveInputVideos(ctx, rc, liveInputID)
+	if err != nil {
+		return StreamVideo{}, err
+	}
+
+	for _, video := range videos {
+		if video.Status.State == StreamVideoStateLiveInProgress {
+			return video, nil
+		}
+	}
+	return StreamVideo{}, ErrNoCurrentLiveInputRecording
+}
+
+// EnsureStreamLiveInputRecordingsReady checks that every recording of a live
+// input has finished recording and processing, returning
+// ErrLiveInputRecordingsNotReady listing the recordings which have not. It is
+// meant to be called before DeleteStreamLiveInput by teardown scripts which
+// should not delete a live input while a broadcast is still being recorded.
+//
+// Recordings which failed to process are not waited for.
+func (api *API) EnsureStreamLiveInputRecordingsReady(ctx context.Context, rc *ResourceContainer, liveInputID string) error {
+	videos, err := api.ListStreamLiveInputVideos(ctx, rc, liveInputID)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, video := range videos {
+		if !video.IsReady() && !video.Status.IsErrored() {
+			pending = append(pending, fmt.Sprintf("%s (%s)", video.UID, video.Status.State))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %s", ErrLiveInputRecordingsNotReady, strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// SortStreamVideosByCreated sorts videos, such as the recordings returned by
+// ListStreamLiveInputVideos, by creation time. The order is oldest first when
+// ascending and newest first otherwise. Videos without a creation time are
+// placed last.
+func SortStreamVideosByCreated(videos []StreamVideo, ascending bool) {
+	sort.SliceStable(videos, func(i, j int) bool {
+		a, b := videos[i].Created, videos[j].Created
+		if a == nil || b == nil {
+			return a != nil
+		}
+		if ascending {
+			return a.Before(*b)
+		}
+		return a.After(*b)
+	})
+}
+
+// StreamRecordingReadyLatency returns the time between the live input
+// disconnecting at the end of a recording and the recorded video becoming
+// ready to stream. The disconnect is the first disconnected status entered
+// after the video was created.
+//
+// The returned boolean is false when any of the timestamps needed are
+// missing, such as for a recording which is still in progress.
+func StreamRecordingReadyLatency(video StreamVideo, statuses *StreamLiveInputStatuses) (time.Duration, bool) {
+	if video.Created == nil || video.ReadyToStreamAt == nil || statuses == nil {
+		return 0, false
+	}
+
+	var disconnected *time.Time
+	for _, status := range append([]StreamLiveInputStatus{statuses.Current}, statuses.History...) {
+		if status.State != StreamLiveInputStateDisconnected || status.StatusEnteredAt == nil {
+			continue
+		}
+		if status.StatusEnteredAt.Before(*video.Created) {
+			continue
+		}
+		if disconnected == nil || status.StatusEnteredAt.Before(*disconnected) {
+			disconnected = status.StatusEnteredAt
+		}
+	}
+
+	if disconnected == nil || video.ReadyToStreamAt.Before(*disconnected) {
+		return 0, false
+	}
+
+	return video.ReadyToStreamAt.Sub(*disconnected), true
+}
@@ -0,0 +1,240 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// ErrMissingOutputID is for when OutputID is required but missing.
+	ErrMissingOutputID = errors.New("required output id missing")
+	// ErrMissingOutputURL is for when the URL of an output is required but missing.
+	ErrMissingOutputURL = errors.New("required output url missing")
+)
+
+// StreamLiveInputOutput represents an output of a live input, a destination
+// the broadcast is simulcast to.
+type StreamLiveInputOutput struct {
+	UID       string `json:"uid,omitempty"`
+	URL       string `json:"url,omitempty"`
+	StreamKey string `json:"streamKey,omitempty"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// CreateStreamLiveInputOutputParameters are the parameters used when adding
+// an output to a live input.
+type CreateStreamLiveInputOutputParameters struct {
+	LiveInputID string `json:"-"`
+	URL         string `json:"url"`
+	StreamKey   string `json:"streamKey"`
+	Enabled     *bool  `json:"enabled,omitempty"`
+}
+
+// UpdateStreamLiveInputOutputParameters are the parameters used when
+// updating an output of a live input.
+type UpdateStreamLiveInputOutputParameters struct {
+	LiveInputID string `json:"-"`
+	OutputID    string `json:"-"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// StreamLiveInputOutputResponse represents an API response of a live input
+// output.
+type StreamLiveInputOutputResponse struct {
+	Response
+	Result StreamLiveInputOutput `json:"result,omitempty"`
+}
+
+// StreamLiveInputOutputListResponse represents an API response of listing
+// the outputs of a live input.
+type StreamLiveInputOutputListResponse struct {
+	Response
+	Result []StreamLiveInputOutput `json:"result,omitempty"`
+}
+
+// CreateStreamLiveInputOutput adds an output to a live input.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-live-inputs-create-a-new-output,-connected-to-a-live-input
+func (api *API) CreateStreamLiveInputOutput(ctx context.Context, rc *ResourceContainer, params CreateStreamLiveInputOutputParameters) (StreamLiveInputOutput, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamLiveInputOutput{}, err
+	}
+
+	if params.LiveInputID == "" {
+		return StreamLiveInputOutput{}, ErrMissingLiveInputID
+	}
+
+	if params.URL == "" {
+		return StreamLiveInputOutput{}, ErrMissingOutputURL
+	}
+
+	uri := fmt.Sprintf("%s/live_inputs/%s/outputs", streamBasePath(rc.Identifier), params.LiveInputID)
+	res, err := api.makeRequestContext(ctx, http.MethodPost, uri, params)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "CreateStreamLiveInputOutput", AccountID: rc.Identifier, LiveInputID: params.LiveInputID}, err)
+	if err != nil {
+		return StreamLiveInputOutput{}, wrapStreamTransportError("CreateStreamLiveInputOutput", uri, err)
+	}
+
+	var r StreamLiveInputOutputResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return StreamLiveInputOutput{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	return r.Result, nil
+}
+
+// ListStreamLiveInputOutputs lists the outputs of a live input.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-live-inputs-list-all-outputs-associated-with-a-specified-live-input
+func (api *API) ListStreamLiveInputOutputs(ctx context.Context, rc *ResourceContainer, liveInputID string) ([]StreamLiveInputOutput, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return []StreamLiveInputOutput{}, err
+	}
+
+	if liveInputID == "" {
+		return []StreamLiveInputOutput{}, ErrMissingLiveInputID
+	}
+
+	uri := fmt.Sprintf("%s/live_inputs/%s/outputs", streamBasePath(rc.Identifier), liveInputID)
+	res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return []StreamLiveInputOutput{}, wrapStreamTransportError("ListStreamLiveInputOutputs", uri, err)
+	}
+
+	var r StreamLiveInputOutputListResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return []StreamLiveInputOutput{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	return r.Result, nil
+}
+
+// UpdateStreamLiveInputOutput enables or disables an output of a live input.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-live-inputs-update-an-output
+func (api *API) UpdateStreamLiveInputOutput(ctx context.Context, rc *ResourceContainer, params UpdateStreamLiveInputOutputParameters) (StreamLiveInputOutput, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamLiveInputOutput{}, err
+	}
+
+	if params.LiveInputID == "" {
+		return StreamLiveInputOutput{}, ErrMissingLiveInputID
+	}
+
+	if params.OutputID == "" {
+		return StreamLiveInputOutput{}, ErrMissingOutputID
+	}
+
+	uri := fmt.Sprintf("%s/live_inputs/%s/outputs/%s", streamBasePath(rc.Identifier), params.LiveInputID, params.OutputID)
+	res, err := api.makeRequestContext(ctx, http.MethodPut, uri, params)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "UpdateStreamLiveInputOutput", AccountID: rc.Identifier, LiveInputID: params.LiveInputID, OutputID: params.OutputID}, err)
+	if err != nil {
+		return StreamLiveInputOutput{}, wrapStreamTransportError("UpdateStreamLiveInputOutput", uri, err)
+	}
+
+	var r StreamLiveInputOutputResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return StreamLiveInputOutput{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	return r.Result, nil
+}
+
+// DeleteStreamLiveInputOutput removes an output from a live input.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-live-inputs-delete-an-output
+func (api *API) DeleteStreamLiveInputOutput(ctx context.Context, rc *ResourceContainer, liveInputID, outputID string) error {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return err
+	}
+
+	if liveInputID == "" {
+		return ErrMissingLiveInputID
+	}
+
+	if outputID == "" {
+		return ErrMissingOutputID
+	}
+
+	uri := fmt.Sprintf("%s/live_inputs/%s/outputs/%s", streamBasePath(rc.Identifier), liveInputID, outputID)
+	_, err := api.makeRequestContext(ctx, http.MethodDelete, uri, nil)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "DeleteStreamLiveInputOutput", AccountID: rc.Identifier, LiveInputID: liveInputID, OutputID: outputID}, err)
+	if err != nil {
+		return wrapStreamTransportError("DeleteStreamLiveInputOutput", uri, err)
+	}
+	return nil
+}
+
+// SetAllLiveInputOutputs enables or disables every output of a live input,
+// updating the outputs concurrently. Outputs which could not be updated are
+// returned as StreamBulkErrors keyed by output UID.
+func (api *API) SetAllLiveInputOutputs(ctx context.Context, rc *ResourceContainer, liveInputID string, enabled bool, opts ...StreamBulkOption) error {
+	outputs, err := api.ListStreamLiveInputOutputs(ctx, rc, liveInputID)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		ids = append(ids, output.UID)
+	}
+
+	op := func(id string) StreamBulkOperation {
+		return StreamBulkOperation{Method: http.MethodPut, URI: fmt.Sprintf("%s/live_inputs/%s/outputs/%s", streamBasePath(rc.Identifier), liveInputID, id), TargetID: id}
+	}
+	return streamBulkChange(ctx, ids, op, func(ctx context.Context, id string) error {
+		_, err := api.UpdateStreamLiveInputOutput(ctx, rc, UpdateStreamLiveInputOutputParameters{
+			LiveInputID: liveInputID,
+			OutputID:    id,
+			Enabled:     enabled,
+		})
+		return err
+	}, opts...)
+}
+
+// CreateStreamLiveInputWithOutputsParameters are the parameters used when
+// creating a live input together with its outputs.
+type CreateStreamLiveInputWithOutputsParameters struct {
+	LiveInput CreateStreamLiveInputParameters
+
+	// Outputs are added to the live input in order. Their LiveInputID is set
+	// to the UID of the created live input.
+	Outputs []CreateStreamLiveInputOutputParameters
+
+	// RollbackOnError deletes the live input if any of the outputs could not
+	// be added.
+	RollbackOnError bool
+}
+
+// CreateStreamLiveInputWithOutputs creates a live input and then adds each
+// of the outputs to it. If adding an output fails the live input and the
+// outputs added so far are returned along with the error, unless
+// RollbackOnError is set in which case the live input is deleted first.
+func (api *API) CreateStreamLiveInputWithOutputs(ctx context.Context, rc *ResourceContainer, params CreateStreamLiveInputWithOutputsParameters) (StreamLiveInput, []StreamLiveInputOutput, error) {
+	input, err := api.CreateStreamLiveInput(ctx, rc, params.LiveInput)
+	if err != nil && !errors.Is(err, ErrAllowedOriginsWithoutSignedURLs) {
+		return StreamLiveInput{}, nil, err
+	}
+	warning := err
+
+	outputs := make([]StreamLiveInputOutput, 0, len(params.Outputs))
+	for _, outputParams := range params.Outputs {
+		outputParams.LiveInputID = input.UID
+		output, err := api.CreateStreamLiveInputOutput(ctx, rc, outputParams)
+		if err != nil {
+			err = fmt.Errorf("failed to add output %s to live input %s: %w", outputParams.URL, input.UID, err)
+			if !params.RollbackOnError {
+				return input, outputs, err
+			}
+
+			if deleteErr := api.DeleteStreamLiveInput(ctx, rc, input.UID); deleteErr != nil {
+				return input, outputs, fmt.Errorf("%w (rolling back live input failed: %s)", err, deleteErr)
+			}
+			return StreamLiveInput{}, nil, err
+		}
+		outputs = append(outputs, output)
+	}
+
+	return input, outputs, warning
+}
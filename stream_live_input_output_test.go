@@ -0,0 +1,246 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLiveInputOutputID = "baea4d9c515887b80289d5c33cf01145"
+
+func TestStream_CreateStreamLiveInputOutput(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/outputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"url":"rtmp://a.rtmp.youtube.com/live2","streamKey":"uzya-f19y-g2g9-a2ee-51j2"}`, string(b))
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "uid": "%s",
+    "url": "rtmp://a.rtmp.youtube.com/live2",
+    "streamKey": "uzya-f19y-g2g9-a2ee-51j2",
+    "enabled": true
+  }
+}`, testLiveInputOutputID)
+	})
+
+	_, err := client.CreateStreamLiveInputOutput(context.Background(), AccountIdentifier(""), CreateStreamLiveInputOutputParameters{})
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	_, err = client.CreateStreamLiveInputOutput(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputOutputParameters{})
+	assert.Equal(t, ErrMissingLiveInputID, err)
+
+	_, err = client.CreateStreamLiveInputOutput(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputOutputParameters{LiveInputID: testLiveInputID})
+	assert.Equal(t, ErrMissingOutputURL, err)
+
+	out, err := client.CreateStreamLiveInputOutput(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputOutputParameters{
+		LiveInputID: testLiveInputID,
+		URL:         "rtmp://a.rtmp.youtube.com/live2",
+		StreamKey:   "uzya-f19y-g2g9-a2ee-51j2",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StreamLiveInputOutput{
+			UID:       testLiveInputOutputID,
+			URL:       "rtmp://a.rtmp.youtube.com/live2",
+			StreamKey: "uzya-f19y-g2g9-a2ee-51j2",
+			Enabled:   true,
+		}, out)
+	}
+}
+
+func TestStream_ListStreamLiveInputOutputs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/outputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": [
+    {"uid": "%s", "url": "rtmp://a.rtmp.youtube.com/live2", "streamKey": "uzya-f19y-g2g9-a2ee-51j2", "enabled": false}
+  ]
+}`, testLiveInputOutputID)
+	})
+
+	_, err := client.ListStreamLiveInputOutputs(context.Background(), AccountIdentifier(testAccountID), "")
+	assert.Equal(t, ErrMissingLiveInputID, err)
+
+	out, err := client.ListStreamLiveInputOutputs(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []StreamLiveInputOutput{{
+			UID:       testLiveInputOutputID,
+			URL:       "rtmp://a.rtmp.youtube.com/live2",
+			StreamKey: "uzya-f19y-g2g9-a2ee-51j2",
+		}}, out)
+	}
+}
+
+func TestStream_UpdateStreamLiveInputOutput(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/outputs/"+testLiveInputOutputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method, "Expected method 'PUT', got %s", r.Method)
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"enabled":false}`, string(b))
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "enabled": false}}`, testLiveInputOutputID)
+	})
+
+	_, err := client.UpdateStreamLiveInputOutput(context.Background(), AccountIdentifier(testAccountID), UpdateStreamLiveInputOutputParameters{LiveInputID: testLiveInputID})
+	assert.Equal(t, ErrMissingOutputID, err)
+
+	out, err := client.UpdateStreamLiveInputOutput(context.Background(), AccountIdentifier(testAccountID), UpdateStreamLiveInputOutputParameters{
+		LiveInputID: testLiveInputID,
+		OutputID:    testLiveInputOutputID,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StreamLiveInputOutput{UID: testLiveInputOutputID}, out)
+	}
+}
+
+func TestStream_DeleteStreamLiveInputOutput(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/outputs/"+testLiveInputOutputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method, "Expected method 'DELETE', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": ""}`)
+	})
+
+	err := client.DeleteStreamLiveInputOutput(context.Background(), AccountIdentifier(testAccountID), testLiveInputID, "")
+	assert.Equal(t, ErrMissingOutputID, err)
+
+	err = client.DeleteStreamLiveInputOutput(context.Background(), AccountIdentifier(testAccountID), testLiveInputID, testLiveInputOutputID)
+	assert.NoError(t, err)
+}
+
+func TestStream_SetAllLiveInputOutputs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	base := "/accounts/" + testAccountID + "/stream/live_inputs/" + testLiveInputID + "/outputs"
+	mux.HandleFunc(base, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [
+  {"uid": "output-1", "enabled": true},
+  {"uid": "output-2", "enabled": true},
+  {"uid": "output-3", "enabled": true}
+]}`)
+	})
+
+	var mu sync.Mutex
+	updated := map[string]bool{}
+	mux.HandleFunc(base+"/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method, "Expected method 'PUT', got %s", r.Method)
+		id := strings.TrimPrefix(r.URL.Path, base+"/")
+
+		w.Header().Set("content-type", "application/json")
+		if id == "output-2" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"success": false, "errors": [{"code": 10000, "message": "output unavailable"}], "messages": [], "result": null}`)
+			return
+		}
+
+		var body StreamLiveInputOutput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		updated[id] = body.Enabled
+		mu.Unlock()
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "enabled": false}}`, id)
+	})
+
+	err := client.SetAllLiveInputOutputs(context.Background(), AccountIdentifier(testAccountID), testLiveInputID, false)
+	var bulkErrs StreamBulkErrors
+	if assert.True(t, errors.As(err, &bulkErrs)) {
+		assert.Len(t, bulkErrs, 1)
+		assert.Contains(t, bulkErrs, "output-2")
+	}
+	assert.Equal(t, map[string]bool{"output-1": false, "output-3": false}, updated)
+}
+
+func TestStream_CreateStreamLiveInputWithOutputs(t *testing.T) {
+	for _, rollback := range []bool{false, true} {
+		t.Run(fmt.Sprintf("rollback=%t", rollback), func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			deleted := false
+			mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+				w.Header().Set("content-type", "application/json")
+				fmt.Fprint(w, singleStreamLiveInputResponse)
+			})
+			mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodDelete, r.Method, "Expected method 'DELETE', got %s", r.Method)
+				deleted = true
+				w.Header().Set("content-type", "application/json")
+				fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": ""}`)
+			})
+			mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/outputs", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+				var body StreamLiveInputOutput
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+				w.Header().Set("content-type", "application/json")
+				if strings.Contains(body.URL, "broken") {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"success": false, "errors": [{"code": 10000, "message": "invalid output url"}], "messages": [], "result": null}`)
+					return
+				}
+				fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "output-1", "url": "%s", "enabled": true}}`, body.URL)
+			})
+
+			params := CreateStreamLiveInputWithOutputsParameters{
+				Outputs: []CreateStreamLiveInputOutputParameters{
+					{URL: "rtmp://a.rtmp.youtube.com/live2", StreamKey: "key-1"},
+				},
+				RollbackOnError: rollback,
+			}
+
+			input, outputs, err := client.CreateStreamLiveInputWithOutputs(context.Background(), AccountIdentifier(testAccountID), params)
+			if assert.NoError(t, err) {
+				assert.Equal(t, testLiveInputID, input.UID)
+				assert.Equal(t, []StreamLiveInputOutput{{UID: "output-1", URL: "rtmp://a.rtmp.youtube.com/live2", Enabled: true}}, outputs)
+			}
+
+			params.Outputs = append(params.Outputs, CreateStreamLiveInputOutputParameters{URL: "rtmp://broken.example.com/live", StreamKey: "key-2"})
+			input, outputs, err = client.CreateStreamLiveInputWithOutputs(context.Background(), AccountIdentifier(testAccountID), params)
+			assert.Error(t, err)
+			assert.Equal(t, rollback, deleted)
+			if rollback {
+				assert.Empty(t, input.UID)
+				assert.Empty(t, outputs)
+			} else {
+				assert.Equal(t, testLiveInputID, input.UID)
+				assert.Len(t, outputs, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package cloudflare
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// StreamLiveInputStatusCache keeps the statuses of a set of live inputs up to
+// date in the background, for dashboards tracking many live inputs.
+type StreamLiveInputStatusCache struct {
+	api         *API
+	rc          *ResourceContainer
+	liveInputs  []string
+	rateLimiter *rate.Limiter
+
+	// newTicker is replaced in tests to drive refreshes manually.
+	newTicker func(d time.Duration) (<-chan time.Time, func())
+
+	mu       sync.RWMutex
+	statuses map[string]StreamLiveInputStatus
+	err      error
+}
+
+// defaultStreamLiveInputStatusCacheRate is the number of requests per second
+// made by refreshes when no positive rate is given, matching the default rate
+// limit of the API client.
+const defaultStreamLiveInputStatusCacheRate = 4
+
+// NewStreamLiveInputStatusCache creates a cache of the statuses of the given
+// live inputs. Refreshes make at most requestsPerSecond requests per second,
+// on top of the rate limit of the API client. A requestsPerSecond of 0 or
+// less makes 4 requests per second.
+func NewStreamLiveInputStatusCache(api *API, rc *ResourceContainer, liveInputIDs []string, requestsPerSecond float64) *StreamLiveInputStatusCache {
+	if requestsPerSecond <= 0 {
+		requestsPerSecond = defaultStreamLiveInputStatusCacheRate
+	}
+
+	return &StreamLiveInputStatusCache{
+		api:         api,
+		rc:          rc,
+		liveInputs:  liveInputIDs,
+		rateLimiter: rate.NewLimiter(rate.Limit(requestsPerSecond), 1),
+		newTicker: func(d time.Duration) (<-chan time.Time, func()) {
+			ticker := time.NewTicker(d)
+			return ticker.C, ticker.Stop
+		},
+		statuses: make(map[string]StreamLiveInputStatus, len(liveInputIDs)),
+	}
+}
+
+// Start refreshes the cache immediately and then every interval, in the
+// background, until ctx is done. An interval of 0 or less refreshes every 2
+// seconds. The error of the latest refresh is available from Err.
+func (c *StreamLiveInputStatusCache) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultStreamPollMinInterval
+	}
+
+	ticks, stop := c.newTicker(interval)
+	go func() {
+		defer stop()
+		for {
+			c.Refresh(ctx) //nolint:errcheck
+
+			select {
+			case <-ticks:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Refresh fetches the current status of every live input once. Live inputs
+// which could not be fetched keep their previously cached status, and their
+// errors are returned as StreamBulkErrors keyed by live input UID. The
+// context error is returned if ctx is done before every live input was
+// fetched.
+func (c *StreamLiveInputStatusCache) Refresh(ctx context.Context) error {
+	err := c.refresh(ctx)
+
+	c.mu.Lock()
+	c.err = err
+	c.mu.Unlock()
+
+	return err
+}
+
+func (c *StreamLiveInputStatusCache) refresh(ctx context.Context) error {
+	errs := StreamBulkErrors{}
+	for _, id := range c.liveInputs {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		input, err := c.api.GetStreamLiveInput(ctx, c.rc, id)
+		if err != nil {
+			errs[id] = err
+			continue
+		}
+		if input.Status == nil {
+			continue
+		}
+
+		c.mu.Lock()
+		c.statuses[id] = input.Status.Current
+		c.mu.Unlock()
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Err returns the error of the latest refresh, or nil if it succeeded or no
+// refresh has completed yet.
+func (c *StreamLiveInputStatusCache) Err() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.err
+}
+
+// Get returns the cached current status of a live input. The returned
+// boolean is false when the status has not been fetched yet.
+func (c *StreamLiveInputStatusCache) Get(liveInputID string) (StreamLiveInputStatus, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	status, ok := c.statuses[liveInputID]
+	return status, ok
+}
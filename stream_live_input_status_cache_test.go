@@ -0,0 +1,148 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/time/rate"
+)
+
+func TestStreamLiveInputStatusCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	state := StreamLiveInputStateConnected
+	requests := map[string]int{}
+	for _, id := range []string{"input-1", "input-2"} {
+		id := id
+		mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+id, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+			mu.Lock()
+			requests[id]++
+			current := state
+			mu.Unlock()
+
+			w.Header().Set("content-type", "application/json")
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "status": {"current": {"state": "%s"}}}}`, id, current)
+		})
+	}
+
+	cache := NewStreamLiveInputStatusCache(client, AccountIdentifier(testAccountID), []string{"input-1", "input-2"}, 1000)
+	ticks := make(chan time.Time)
+	cache.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+		assert.Equal(t, time.Minute, d)
+		return ticks, func() {}
+	}
+
+	_, ok := cache.Get("input-1")
+	assert.False(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cache.Start(ctx, time.Minute)
+
+	// the first refresh happens immediately
+	assert.Eventually(t, func() bool {
+		status, ok := cache.Get("input-2")
+		return ok && status.State == StreamLiveInputStateConnected
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	state = StreamLiveInputStateDisconnected
+	mu.Unlock()
+
+	ticks <- time.Now()
+	assert.Eventually(t, func() bool {
+		s1, _ := cache.Get("input-1")
+		s2, _ := cache.Get("input-2")
+		return s1.State == StreamLiveInputStateDisconnected && s2.State == StreamLiveInputStateDisconnected
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	assert.Equal(t, map[string]int{"input-1": 2, "input-2": 2}, requests)
+	mu.Unlock()
+}
+
+func TestStreamLiveInputStatusCache_RateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"status": {"current": {"state": "connected"}}}}`)
+	})
+
+	cache := NewStreamLiveInputStatusCache(client, AccountIdentifier(testAccountID), []string{"input-1", "input-2", "input-3"}, 20)
+
+	start := time.Now()
+	cache.Refresh(context.Background())
+	// the first request is immediate, the following two wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	for _, id := range []string{"input-1", "input-2", "input-3"} {
+		_, ok := cache.Get(id)
+		assert.True(t, ok, id)
+	}
+}
+
+func TestStreamLiveInputStatusCache_Errors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/input-1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "input-1", "status": {"current": {"state": "connected"}}}}`)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/input-2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10003, "message": "live input not found"}], "messages": [], "result": null}`)
+	})
+
+	cache := NewStreamLiveInputStatusCache(client, AccountIdentifier(testAccountID), []string{"input-1", "input-2"}, 1000)
+	assert.NoError(t, cache.Err())
+
+	err := cache.Refresh(context.Background())
+	var bulkErrs StreamBulkErrors
+	require.True(t, errors.As(err, &bulkErrs))
+	assert.Len(t, bulkErrs, 1)
+	var notFound *NotFoundError
+	assert.True(t, errors.As(bulkErrs["input-2"], &notFound))
+	assert.Equal(t, err, cache.Err())
+
+	_, ok := cache.Get("input-1")
+	assert.True(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, cache.Refresh(ctx), context.Canceled)
+	assert.ErrorIs(t, cache.Err(), context.Canceled)
+}
+
+func TestStreamLiveInputStatusCache_Defaults(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cache := NewStreamLiveInputStatusCache(client, AccountIdentifier(testAccountID), nil, 0)
+	assert.Equal(t, rate.Limit(defaultStreamLiveInputStatusCacheRate), cache.rateLimiter.Limit())
+
+	intervals := make(chan time.Duration, 1)
+	cache.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+		intervals <- d
+		return make(chan time.Time), func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cache.Start(ctx, 0)
+	assert.Equal(t, defaultStreamPollMinInterval, <-intervals)
+}
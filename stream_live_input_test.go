@@ -0,0 +1,1672 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testLiveInputID = "66be4bf738797e01e1fca35a7bdecdcd"
+
+	singleStreamLiveInputResponse = `
+{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "uid": "66be4bf738797e01e1fca35a7bdecdcd",
+    "created": "2014-01-02T02:20:00Z",
+    "modified": "2014-01-02T02:20:00Z",
+    "meta": {
+      "name": "test stream 1"
+    },
+    "defaultCreator": "creator-id_abcde12345",
+    "deleteRecordingAfterDays": 45,
+    "recording": {
+      "mode": "automatic",
+      "requireSignedURLs": false,
+      "allowedOrigins": [
+        "example.com"
+      ],
+      "timeoutSeconds": 10
+    },
+    "rtmps": {
+      "url": "rtmps://live.cloudflare.com:443/live/",
+      "streamKey": "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada"
+    },
+    "rtmpsPlayback": {
+      "url": "rtmps://live.cloudflare.com:443/live/",
+      "streamKey": "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada"
+    },
+    "srt": {
+      "url": "srt://live.cloudflare.com:778",
+      "streamId": "f256e6ea9341d51eea64c9454659e576",
+      "passphrase": "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada"
+    },
+    "srtPlayback": {
+      "url": "rtmps://live.cloudflare.com:443/live/",
+      "streamId": "f256e6ea9341d51eea64c9454659e576",
+      "passphrase": "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada"
+    },
+    "webRTC": {
+      "url": "https://customer-m033z5x00ks6nunl.cloudflarestream.com/b236bde30eb07b9d01318940e5fc3edake34a3efb3896e18f2dc277ce6cc993ad/webRTC/publish"
+    },
+    "webRTCPlayback": {
+      "url": "https://customer-m033z5x00ks6nunl.cloudflarestream.com/b236bde30eb07b9d01318940e5fc3edake34a3efb3896e18f2dc277ce6cc993ad/webRTC/play"
+    },
+    "status": {
+      "current": {
+        "state": "connected",
+        "ingestProtocol": "rtmp",
+        "statusEnteredAt": "2014-01-02T02:20:00Z",
+        "statusLastSeen": "2014-01-02T02:25:00Z"
+      },
+      "history": [
+        {
+          "state": "disconnected",
+          "reason": "client_disconnect",
+          "ingestProtocol": "rtmp",
+          "statusEnteredAt": "2014-01-01T02:20:00Z",
+          "statusLastSeen": "2014-01-01T02:25:00Z"
+        }
+      ]
+    }
+  }
+}
+`
+)
+
+func testStreamLiveInput() StreamLiveInput {
+	created, _ := time.Parse(time.RFC3339, "2014-01-02T02:20:00Z")
+	currentLastSeen, _ := time.Parse(time.RFC3339, "2014-01-02T02:25:00Z")
+	historyEntered, _ := time.Parse(time.RFC3339, "2014-01-01T02:20:00Z")
+	historyLastSeen, _ := time.Parse(time.RFC3339, "2014-01-01T02:25:00Z")
+
+	return StreamLiveInput{
+		UID:      testLiveInputID,
+		Created:  &created,
+		Modified: &created,
+		Meta: map[string]interface{}{
+			"name": "test stream 1",
+		},
+		DefaultCreator:           "creator-id_abcde12345",
+		DeleteRecordingAfterDays: 45,
+		Recording: StreamLiveInputRecording{
+			Mode:           StreamLiveInputRecordingModeAutomatic,
+			AllowedOrigins: []string{"example.com"},
+			TimeoutSeconds: 10,
+		},
+		RTMPS: StreamLiveInputRTMPS{
+			URL:       "rtmps://live.cloudflare.com:443/live/",
+			StreamKey: "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada",
+		},
+		RTMPSPlayback: StreamLiveInputRTMPS{
+			URL:       "rtmps://live.cloudflare.com:443/live/",
+			StreamKey: "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada",
+		},
+		SRT: StreamLiveInputSRT{
+			URL:        "srt://live.cloudflare.com:778",
+			StreamID:   "f256e6ea9341d51eea64c9454659e576",
+			Passphrase: "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada",
+		},
+		SRTPlayback: StreamLiveInputSRT{
+			URL:        "rtmps://live.cloudflare.com:443/live/",
+			StreamID:   "f256e6ea9341d51eea64c9454659e576",
+			Passphrase: "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada",
+		},
+		WebRTC: StreamLiveInputWebRTC{
+			URL: "https://customer-m033z5x00ks6nunl.cloudflarestream.com/b236bde30eb07b9d01318940e5fc3edake34a3efb3896e18f2dc277ce6cc993ad/webRTC/publish",
+		},
+		WebRTCPlayback: StreamLiveInputWebRTC{
+			URL: "https://customer-m033z5x00ks6nunl.cloudflarestream.com/b236bde30eb07b9d01318940e5fc3edake34a3efb3896e18f2dc277ce6cc993ad/webRTC/play",
+		},
+		Status: &StreamLiveInputStatuses{
+			Current: StreamLiveInputStatus{
+				State:           "connected",
+				IngestProtocol:  "rtmp",
+				StatusEnteredAt: &created,
+				StatusLastSeen:  &currentLastSeen,
+			},
+			History: []StreamLiveInputStatus{
+				{
+					State:           "disconnected",
+					Reason:          "client_disconnect",
+					IngestProtocol:  "rtmp",
+					StatusEnteredAt: &historyEntered,
+					StatusLastSeen:  &historyLastSeen,
+				},
+			},
+		},
+	}
+}
+
+func TestStream_CreateStreamLiveInput(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"meta":{"name":"test stream 1"},"recording":{"mode":"automatic"}}`, string(b))
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	_, err := client.CreateStreamLiveInput(context.Background(), AccountIdentifier(""), CreateStreamLiveInputParameters{})
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingAccountID, err)
+	}
+
+	out, err := client.CreateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{
+		Meta:      map[string]interface{}{"name": "test stream 1"},
+		Recording: &StreamLiveInputRecording{Mode: StreamLiveInputRecordingModeAutomatic},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, testStreamLiveInput(), out)
+	}
+}
+
+func TestStream_CreateStreamLiveInput_Meta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(b)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	_, err := client.CreateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{
+		Meta: map[string]interface{}{"name": "test stream 1", "season": 2, "rating": 4.5, "featured": true},
+	})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"meta":{"name":"test stream 1","season":"2","rating":"4.5","featured":"true"}}`, body)
+	}
+
+	body = ""
+	_, err = client.CreateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{
+		Meta: map[string]interface{}{"tags": map[string]interface{}{"genre": "news"}},
+	})
+	assert.True(t, errors.Is(err, ErrInvalidStreamMeta))
+	assert.Empty(t, body, "invalid meta must not be sent")
+}
+
+func TestStream_CreateStreamLiveInputWithResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	out, metadata, err := client.CreateStreamLiveInputWithResponse(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, testStreamLiveInput(), out)
+		assert.Equal(t, http.StatusCreated, metadata.StatusCode)
+		assert.Equal(t, "application/json", metadata.Headers.Get("content-type"))
+	}
+}
+
+func BenchmarkListStreamLiveInputs(b *testing.B) {
+	setup()
+	defer teardown()
+
+	items := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		items = append(items, fmt.Sprintf(`{"uid": "%032x", "created": "2014-01-02T02:20:00Z", "modified": "2014-01-02T02:20:00Z", "meta": {"name": "test stream %d"}}`, i, i))
+	}
+	response := `{"success": true, "errors": [], "messages": [], "result": {"liveInputs": [` + strings.Join(items, ",") + `], "range": 200, "total": 200}}`
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, response)
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.ListStreamLiveInputs(context.Background(), AccountIdentifier(testAccountID), ListStreamLiveInputsParameters{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestStream_EmptyResultErrors(t *testing.T) {
+	for name, enabled := range map[string]bool{"enabled": true, "disabled": false} {
+		t.Run(name, func(t *testing.T) {
+			setup(UsingEmptyResultErrors(enabled))
+			defer teardown()
+
+			empty := func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("content-type", "application/json")
+				fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {}}`)
+			}
+			mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, empty)
+			mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, empty)
+
+			input, err := client.GetStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+			video, videoErr := client.StreamGetVideo(context.Background(), StreamParameters{AccountID: testAccountID, VideoID: testVideoID})
+			if enabled {
+				assert.ErrorIs(t, err, ErrEmptyResult)
+				assert.ErrorIs(t, videoErr, ErrEmptyResult)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, StreamLiveInput{}, input)
+				assert.NoError(t, videoErr)
+				assert.Equal(t, StreamVideo{}, video)
+			}
+		})
+	}
+}
+
+func TestStream_ListStreamLiveInputsWithResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		w.Header().Set("cf-ray", "7d1e5c3b9a2f4e61-LHR")
+		w.Header().Set("ratelimit-remaining", "1199")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {"liveInputs": [{"uid": "%s"}], "range": 1, "total": 1}
+}`, testLiveInputID)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		w.Header().Set("cf-ray", "7d1e5c3b9a2f4e62-LHR")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	_, _, err := client.ListStreamLiveInputsWithResponse(context.Background(), AccountIdentifier(""), ListStreamLiveInputsParameters{})
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	inputs, metadata, err := client.ListStreamLiveInputsWithResponse(context.Background(), AccountIdentifier(testAccountID), ListStreamLiveInputsParameters{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []StreamLiveInputListItem{{UID: testLiveInputID}}, inputs)
+		assert.Equal(t, http.StatusOK, metadata.StatusCode)
+		assert.Equal(t, "7d1e5c3b9a2f4e61-LHR", metadata.Headers.Get("cf-ray"))
+		assert.Equal(t, "1199", metadata.Headers.Get("ratelimit-remaining"))
+	}
+
+	input, metadata, err := client.GetStreamLiveInputWithResponse(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, testStreamLiveInput(), input)
+		assert.Equal(t, "7d1e5c3b9a2f4e62-LHR", metadata.Headers.Get("cf-ray"))
+	}
+}
+
+func TestStream_StreamLiveInputWithResponse_Messages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	response := `{
+  "success": true,
+  "errors": [],
+  "messages": [{"code": 10100, "message": "defaultCreator is deprecated"}],
+  "result": {"uid": "` + testLiveInputID + `"}
+}`
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, response)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method, "Expected method 'PUT', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, response)
+	})
+
+	want := []ResponseInfo{{Code: 10100, Message: "defaultCreator is deprecated"}}
+
+	out, metadata, err := client.CreateStreamLiveInputWithResponse(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, testLiveInputID, out.UID)
+		assert.Equal(t, want, metadata.Messages)
+	}
+
+	_, _, err = client.UpdateStreamLiveInputWithResponse(context.Background(), AccountIdentifier(testAccountID), UpdateStreamLiveInputParameters{})
+	assert.Equal(t, ErrMissingLiveInputID, err)
+
+	out, metadata, err = client.UpdateStreamLiveInputWithResponse(context.Background(), AccountIdentifier(testAccountID), UpdateStreamLiveInputParameters{LiveInputID: testLiveInputID})
+	if assert.NoError(t, err) {
+		assert.Equal(t, testLiveInputID, out.UID)
+		assert.Equal(t, http.StatusOK, metadata.StatusCode)
+		assert.Equal(t, want, metadata.Messages)
+	}
+}
+
+func TestStream_CreateStreamLiveInput_DisableRecording(t *testing.T) {
+	setup(UsingStreamLiveInputDefaults(CreateStreamLiveInputParameters{
+		Recording: &StreamLiveInputRecording{Mode: StreamLiveInputRecordingModeAutomatic},
+	}))
+	defer teardown()
+
+	var body string
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(b)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	_, err := client.CreateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{
+		DisableRecording: true,
+		Recording:        &StreamLiveInputRecording{TimeoutSeconds: 30},
+	})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"recording":{"mode":"off","timeoutSeconds":30}}`, body)
+	}
+
+	for _, recording := range []StreamLiveInputRecording{
+		{Mode: StreamLiveInputRecordingModeAutomatic},
+		{RequireSignedURLs: true},
+		{AllowedOrigins: []string{"example.com"}},
+	} {
+		_, err = client.CreateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{
+			DisableRecording: true,
+			Recording:        &recording,
+		})
+		assert.Equal(t, ErrConflictingLiveInputRecording, err)
+	}
+}
+
+func TestStream_CreateStreamLiveInputForOBS(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	_, err := client.CreateStreamLiveInputForOBS(context.Background(), AccountIdentifier(""), CreateStreamLiveInputParameters{})
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingAccountID, err)
+	}
+
+	out, err := client.CreateStreamLiveInputForOBS(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StreamLiveInputOBSSettings{
+			LiveInputID: testLiveInputID,
+			Server:      "rtmps://live.cloudflare.com:443/live/",
+			StreamKey:   "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada",
+		}, out)
+	}
+}
+
+func TestStream_CreateStreamLiveInput_Defaults(t *testing.T) {
+	setup(UsingStreamLiveInputDefaults(CreateStreamLiveInputParameters{
+		DefaultCreator:           "platform",
+		DeleteRecordingAfterDays: 30,
+		Meta:                     map[string]interface{}{"tenant": "acme", "name": "default"},
+		Recording: &StreamLiveInputRecording{
+			Mode:              StreamLiveInputRecordingModeAutomatic,
+			RequireSignedURLs: true,
+			TimeoutSeconds:    10,
+		},
+	}))
+	defer teardown()
+
+	var body string
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(b)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	// defaults apply when nothing is set
+	_, err := client.CreateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{
+			"defaultCreator": "platform",
+			"deleteRecordingAfterDays": 30,
+			"meta": {"tenant": "acme", "name": "default"},
+			"recording": {"mode": "automatic", "requireSignedURLs": true, "timeoutSeconds": 10}
+		}`, body)
+	}
+
+	// explicit values override the defaults, but a boolean default of true
+	// such as RequireSignedURLs stays enabled
+	params := CreateStreamLiveInputParameters{
+		DefaultCreator: "user-1",
+		Meta:           map[string]interface{}{"name": "my stream"},
+		Recording: &StreamLiveInputRecording{
+			Mode:           StreamLiveInputRecordingModeOff,
+			TimeoutSeconds: 60,
+		},
+	}
+	_, err = client.CreateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), params)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{
+			"defaultCreator": "user-1",
+			"deleteRecordingAfterDays": 30,
+			"meta": {"tenant": "acme", "name": "my stream"},
+			"recording": {"mode": "off", "requireSignedURLs": true, "timeoutSeconds": 60}
+		}`, body)
+	}
+	assert.Equal(t, map[string]interface{}{"name": "my stream"}, params.Meta, "caller meta must not be modified")
+}
+
+func TestStream_CreateStreamLiveInputIfAbsent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "liveInputs": [
+      {"uid": "11111111111111111111111111111111", "meta": {"eventID": "event-1"}},
+      {"uid": "66be4bf738797e01e1fca35a7bdecdcd", "meta": {"eventID": "event-2"}}
+    ],
+    "range": 1000,
+    "total": 2
+  }
+}`)
+		case http.MethodPost:
+			created++
+			fmt.Fprint(w, singleStreamLiveInputResponse)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	_, _, err := client.CreateStreamLiveInputIfAbsent(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{}, "eventID")
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingLiveInputMetaKey, err)
+	}
+
+	// found
+	out, ok, err := client.CreateStreamLiveInputIfAbsent(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{
+		Meta: map[string]interface{}{"eventID": "event-2"},
+	}, "eventID")
+	if assert.NoError(t, err) {
+		assert.False(t, ok)
+		assert.Equal(t, testStreamLiveInput(), out)
+		assert.Equal(t, 0, created)
+	}
+
+	// not found
+	out, ok, err = client.CreateStreamLiveInputIfAbsent(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{
+		Meta: map[string]interface{}{"eventID": "event-3"},
+	}, "eventID")
+	if assert.NoError(t, err) {
+		assert.True(t, ok)
+		assert.Equal(t, testStreamLiveInput(), out)
+		assert.Equal(t, 1, created)
+	}
+}
+
+func TestStream_GetStreamLiveInput(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	_, err := client.GetStreamLiveInput(context.Background(), AccountIdentifier(""), testLiveInputID)
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingAccountID, err)
+	}
+
+	_, err = client.GetStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), "")
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingLiveInputID, err)
+	}
+
+	out, err := client.GetStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, testStreamLiveInput(), out)
+	}
+}
+
+func TestStream_ExistsStreamLiveInput(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10003, "message": "Not Found"}], "messages": [], "result": null}`)
+	})
+
+	exists, err := client.ExistsStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	if assert.NoError(t, err) {
+		assert.True(t, exists)
+	}
+
+	exists, err = client.ExistsStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), "missing")
+	if assert.NoError(t, err) {
+		assert.False(t, exists)
+	}
+
+	_, err = client.ExistsStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), "")
+	assert.Equal(t, ErrMissingLiveInputID, err)
+}
+
+func TestStream_ExistsStreamLiveInput_TransportError(t *testing.T) {
+	transportErr := errors.New("dial tcp: connection refused")
+	setup(HTTPClient(&http.Client{Transport: failingRoundTripper{err: transportErr}}), UsingRetryPolicy(0, 0, 0))
+	defer teardown()
+
+	exists, err := client.ExistsStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	assert.True(t, errors.Is(err, transportErr))
+	assert.False(t, exists)
+}
+
+func TestStream_ListStreamLiveInputs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		assert.Equal(t, "true", r.URL.Query().Get("include_counts"))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "liveInputs": [
+      {
+        "uid": "66be4bf738797e01e1fca35a7bdecdcd",
+        "created": "2014-01-02T02:20:00Z",
+        "modified": "2014-01-02T02:20:00Z",
+        "meta": {
+          "name": "test stream 1"
+        },
+        "deleteRecordingAfterDays": 45
+      }
+    ],
+    "range": 1000,
+    "total": 1
+  }
+}`)
+	})
+
+	_, err := client.ListStreamLiveInputs(context.Background(), AccountIdentifier(""), ListStreamLiveInputsParameters{})
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingAccountID, err)
+	}
+
+	created, _ := time.Parse(time.RFC3339, "2014-01-02T02:20:00Z")
+	want := []StreamLiveInputListItem{{
+		UID:                      testLiveInputID,
+		Created:                  &created,
+		Modified:                 &created,
+		Meta:                     map[string]interface{}{"name": "test stream 1"},
+		DeleteRecordingAfterDays: IntPtr(45),
+	}}
+
+	out, err := client.ListStreamLiveInputs(context.Background(), AccountIdentifier(testAccountID), ListStreamLiveInputsParameters{IncludeCounts: true})
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, out)
+	}
+}
+
+func TestStream_ListStreamLiveInputs_DeleteRecordingAfterDays(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "liveInputs": [
+      {"uid": "input-1", "deleteRecordingAfterDays": 30},
+      {"uid": "input-2", "deleteRecordingAfterDays": 0},
+      {"uid": "input-3"}
+    ],
+    "range": 1000,
+    "total": 3
+  }
+}`)
+	})
+
+	out, err := client.ListStreamLiveInputs(context.Background(), AccountIdentifier(testAccountID), ListStreamLiveInputsParameters{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []StreamLiveInputListItem{
+			{UID: "input-1", DeleteRecordingAfterDays: IntPtr(30)},
+			{UID: "input-2", DeleteRecordingAfterDays: IntPtr(0)},
+			{UID: "input-3"},
+		}, out)
+	}
+}
+
+func TestStream_ListStreamLiveInputs_ModifiedSince(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		assert.Equal(t, "2014-01-02T00:00:00Z", r.URL.Query().Get("modified_since"))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "liveInputs": [
+      {"uid": "66be4bf738797e01e1fca35a7bdecdcd", "modified": "2014-01-02T02:20:00Z"}
+    ],
+    "range": 1000,
+    "total": 1
+  }
+}`)
+	})
+
+	since, _ := time.Parse(time.RFC3339, "2014-01-02T00:00:00Z")
+	modified, _ := time.Parse(time.RFC3339, "2014-01-02T02:20:00Z")
+	out, err := client.ListStreamLiveInputs(context.Background(), AccountIdentifier(testAccountID), ListStreamLiveInputsParameters{ModifiedSince: &since})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []StreamLiveInputListItem{{UID: testLiveInputID, Modified: &modified}}, out)
+	}
+}
+
+func TestStream_ListStreamLiveInputs_RecordingFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "liveInputs": [
+      {
+        "uid": "66be4bf738797e01e1fca35a7bdecdcd",
+        "recording": {
+          "mode": "automatic",
+          "requireSignedURLs": true
+        },
+        "scheduledDeletion": "2014-01-02T02:20:00Z"
+      }
+    ],
+    "range": 1000,
+    "total": 1
+  }
+}`)
+	})
+
+	scheduledDeletion, _ := time.Parse(time.RFC3339, "2014-01-02T02:20:00Z")
+	want := []StreamLiveInputListItem{{
+		UID: testLiveInputID,
+		Recording: StreamLiveInputRecording{
+			Mode:              StreamLiveInputRecordingModeAutomatic,
+			RequireSignedURLs: true,
+		},
+		ScheduledDeletion: &scheduledDeletion,
+	}}
+
+	out, err := client.ListStreamLiveInputs(context.Background(), AccountIdentifier(testAccountID), ListStreamLiveInputsParameters{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, out)
+	}
+}
+
+func TestStream_FilterStreamLiveInputsByMeta(t *testing.T) {
+	items := []StreamLiveInputListItem{
+		{UID: "1", Meta: map[string]interface{}{"name": "morning show", "season": float64(2)}},
+		{UID: "2", Meta: map[string]interface{}{"name": "evening show"}},
+		{UID: "3", Meta: map[string]interface{}{"title": "morning show"}},
+		{UID: "4"},
+	}
+
+	uids := func(items []StreamLiveInputListItem) []string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.UID)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"1"}, uids(FilterStreamLiveInputsByMeta(items, "name", "morning show", StreamMetaMatchExact)))
+	assert.Equal(t, []string{"1"}, uids(FilterStreamLiveInputsByMeta(items, "season", "2", StreamMetaMatchExact)))
+	assert.Empty(t, FilterStreamLiveInputsByMeta(items, "name", "show", StreamMetaMatchExact))
+	assert.Equal(t, []string{"1", "2"}, uids(FilterStreamLiveInputsByMeta(items, "name", "show", StreamMetaMatchSubstring)))
+	assert.Empty(t, FilterStreamLiveInputsByMeta(items, "missing", "", StreamMetaMatchSubstring))
+}
+
+func TestStreamLiveInputRecording_WithTimeout(t *testing.T) {
+	recording := StreamLiveInputRecording{Mode: StreamLiveInputRecordingModeAutomatic, TimeoutSeconds: 5}
+
+	for _, tc := range []struct {
+		timeout time.Duration
+		want    int
+	}{
+		{timeout: 0, want: 0},
+		{timeout: 30 * time.Second, want: 30},
+		{timeout: 2 * time.Minute, want: 120},
+	} {
+		out, err := recording.WithTimeout(tc.timeout)
+		if assert.NoError(t, err, tc.timeout) {
+			assert.Equal(t, StreamLiveInputRecording{Mode: StreamLiveInputRecordingModeAutomatic, TimeoutSeconds: tc.want}, out)
+		}
+	}
+
+	for _, timeout := range []time.Duration{-time.Second, 1500 * time.Millisecond, time.Millisecond} {
+		out, err := recording.WithTimeout(timeout)
+		assert.ErrorIs(t, err, ErrInvalidLiveInputRecordingTimeout, timeout)
+		assert.Equal(t, recording, out, "the settings should be unchanged")
+	}
+}
+
+func TestStream_ProjectStreamLiveInputs(t *testing.T) {
+	modified := time.Date(2014, time.January, 2, 2, 20, 0, 0, time.UTC)
+	items := []StreamLiveInputListItem{
+		{
+			UID:       "1",
+			Modified:  &modified,
+			Meta:      map[string]interface{}{"name": "morning show", "season": float64(2), "notes": "long text"},
+			Recording: StreamLiveInputRecording{Mode: StreamLiveInputRecordingModeAutomatic},
+		},
+		{UID: "2", Meta: map[string]interface{}{"notes": "long text"}},
+	}
+
+	assert.Equal(t, []StreamLiveInputSummary{
+		{UID: "1", Modified: &modified, Meta: map[string]interface{}{"name": "morning show", "season": float64(2)}},
+		{UID: "2"},
+	}, ProjectStreamLiveInputs(items, "name", "season"))
+
+	assert.Equal(t, []StreamLiveInputSummary{
+		{UID: "1", Modified: &modified},
+		{UID: "2"},
+	}, ProjectStreamLiveInputs(items))
+
+	assert.Empty(t, ProjectStreamLiveInputs(nil, "name"))
+}
+
+func TestStreamLiveInput_LivePlaybackURLs(t *testing.T) {
+	input := StreamLiveInput{UID: testLiveInputID}
+	assert.Equal(t, StreamLiveInputPlaybackURLs{
+		HLS:  "https://customer-f33zs165nr7gyfy4.cloudflarestream.com/" + testLiveInputID + "/manifest/video.m3u8",
+		Dash: "https://customer-f33zs165nr7gyfy4.cloudflarestream.com/" + testLiveInputID + "/manifest/video.mpd",
+	}, input.LivePlaybackURLs("customer-f33zs165nr7gyfy4.cloudflarestream.com"))
+
+	input.PreferLowLatency = true
+	assert.Equal(t, StreamLiveInputPlaybackURLs{
+		HLS:  "https://customer-f33zs165nr7gyfy4.cloudflarestream.com/" + testLiveInputID + "/manifest/video.m3u8?protocol=llhls",
+		Dash: "https://customer-f33zs165nr7gyfy4.cloudflarestream.com/" + testLiveInputID + "/manifest/video.mpd",
+	}, input.LivePlaybackURLs("customer-f33zs165nr7gyfy4.cloudflarestream.com"))
+}
+
+func TestStreamLiveInput_RTMPSURLs(t *testing.T) {
+	var input StreamLiveInput
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "uid": "`+testLiveInputID+`",
+  "rtmps": {
+    "url": "rtmps://live.cloudflare.com:443/live/",
+    "streamKey": "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada"
+  },
+  "rtmpsPlayback": {
+    "url": "rtmps://live.cloudflare.com:443/live",
+    "streamKey": "2fbd7b0d3f7f1d2b8c4e6a9f0e1d2c3bk8a9b0c1d2e3f4a5b6c7d8e9f0a1b2c3d"
+  }
+}`), &input))
+
+	assert.Equal(t, "rtmps://live.cloudflare.com:443/live/2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada", input.RTMPSIngestURL())
+	assert.Equal(t, "rtmps://live.cloudflare.com:443/live/2fbd7b0d3f7f1d2b8c4e6a9f0e1d2c3bk8a9b0c1d2e3f4a5b6c7d8e9f0a1b2c3d", input.RTMPSPlaybackURL())
+
+	assert.Empty(t, StreamLiveInput{}.RTMPSIngestURL())
+	assert.Empty(t, StreamLiveInput{}.RTMPSPlaybackURL())
+}
+
+func TestStreamLiveInput_IngestEndpoints(t *testing.T) {
+	var r StreamLiveInputResponse
+	require.NoError(t, json.Unmarshal([]byte(singleStreamLiveInputResponse), &r))
+
+	assert.Equal(t, StreamLiveInputEndpoints{
+		Ingest: []StreamLiveInputEndpoint{
+			{Protocol: StreamLiveInputProtocolRTMPS, URL: "rtmps://live.cloudflare.com:443/live/", StreamKey: "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada"},
+			{Protocol: StreamLiveInputProtocolSRT, URL: "srt://live.cloudflare.com:778", StreamKey: "f256e6ea9341d51eea64c9454659e576", Passphrase: "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada"},
+			{Protocol: StreamLiveInputProtocolWebRTC, URL: "https://customer-m033z5x00ks6nunl.cloudflarestream.com/b236bde30eb07b9d01318940e5fc3edake34a3efb3896e18f2dc277ce6cc993ad/webRTC/publish"},
+		},
+		Playback: []StreamLiveInputEndpoint{
+			{Protocol: StreamLiveInputProtocolRTMPS, URL: "rtmps://live.cloudflare.com:443/live/", StreamKey: "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada"},
+			{Protocol: StreamLiveInputProtocolSRT, URL: "rtmps://live.cloudflare.com:443/live/", StreamKey: "f256e6ea9341d51eea64c9454659e576", Passphrase: "2fb3cb9f17e68a2568d6ebed8d5505eak3ceaf8c9b1f395e1b76b79332497cada"},
+			{Protocol: StreamLiveInputProtocolWebRTC, URL: "https://customer-m033z5x00ks6nunl.cloudflarestream.com/b236bde30eb07b9d01318940e5fc3edake34a3efb3896e18f2dc277ce6cc993ad/webRTC/play"},
+		},
+	}, r.Result.IngestEndpoints())
+
+	assert.Empty(t, StreamLiveInput{SRT: StreamLiveInputSRT{StreamID: "f256e6ea9341d51eea64c9454659e576"}}.IngestEndpoints().Ingest)
+}
+
+func TestStreamLiveInput_Name(t *testing.T) {
+	for name, tc := range map[string]struct {
+		meta string
+		want string
+	}{
+		"present":    {meta: `{"name": "test stream 1"}`, want: "test stream 1"},
+		"absent":     {meta: `{"event": "keynote"}`, want: ""},
+		"no meta":    {meta: `null`, want: ""},
+		"not string": {meta: `{"name": 42}`, want: ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			body := []byte(`{"uid": "` + testLiveInputID + `", "meta": ` + tc.meta + `}`)
+
+			var input StreamLiveInput
+			require.NoError(t, json.Unmarshal(body, &input))
+			var item StreamLiveInputListItem
+			require.NoError(t, json.Unmarshal(body, &item))
+
+			assert.Equal(t, input.Meta, item.Meta)
+			assert.Equal(t, tc.want, input.Name())
+			assert.Equal(t, tc.want, item.Name())
+		})
+	}
+}
+
+func TestStreamLiveInput_OriginAllowed(t *testing.T) {
+	assert.True(t, StreamLiveInput{}.OriginAllowed("https://anything.example"))
+
+	input := StreamLiveInput{Recording: StreamLiveInputRecording{
+		AllowedOrigins: []string{"example.com", "*.Example.net"},
+	}}
+
+	for origin, allowed := range map[string]bool{
+		"example.com":                  true,
+		"https://example.com":          true,
+		"https://example.com:8443":     true,
+		"www.example.com":              false,
+		"https://video.example.net":    true,
+		"https://a.b.EXAMPLE.net":      true,
+		"example.net":                  false,
+		"https://notexample.net":       false,
+		"https://example.org":          false,
+		"https://example.com.evil.org": false,
+	} {
+		assert.Equal(t, allowed, input.OriginAllowed(origin), "origin %q", origin)
+	}
+}
+
+func TestStream_UpdateStreamLiveInput(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method, "Expected method 'PUT', got %s", r.Method)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, float64(45), body["deleteRecordingAfterDays"])
+		assert.NotContains(t, body, "LiveInputID")
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	_, err := client.UpdateStreamLiveInput(context.Background(), AccountIdentifier(""), UpdateStreamLiveInputParameters{})
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingAccountID, err)
+	}
+
+	_, err = client.UpdateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), UpdateStreamLiveInputParameters{})
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingLiveInputID, err)
+	}
+
+	out, err := client.UpdateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), UpdateStreamLiveInputParameters{
+		LiveInputID:              testLiveInputID,
+		DeleteRecordingAfterDays: 45,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, testStreamLiveInput(), out)
+	}
+}
+
+func TestStream_UpdateStreamLiveInput_Recording(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method, "Expected method 'PUT', got %s", r.Method)
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(b)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	for name, tc := range map[string]struct {
+		recording *StreamLiveInputRecording
+		want      string
+	}{
+		"nil":       {nil, `{"defaultCreator":"user-1"}`},
+		"empty":     {&StreamLiveInputRecording{}, `{"defaultCreator":"user-1","recording":{}}`},
+		"populated": {&StreamLiveInputRecording{Mode: StreamLiveInputRecordingModeAutomatic}, `{"defaultCreator":"user-1","recording":{"mode":"automatic"}}`},
+	} {
+		_, err := client.UpdateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), UpdateStreamLiveInputParameters{
+			LiveInputID:    testLiveInputID,
+			DefaultCreator: StringPtr("user-1"),
+			Recording:      tc.recording,
+		})
+		if assert.NoError(t, err, name) {
+			assert.JSONEq(t, tc.want, body, name)
+		}
+	}
+}
+
+func TestStream_UpdateStreamLiveInput_DefaultCreator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(b)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	for name, tc := range map[string]struct {
+		defaultCreator *string
+		want           string
+	}{
+		"unchanged": {nil, `{"deleteRecordingAfterDays":45}`},
+		"cleared":   {StringPtr(""), `{"deleteRecordingAfterDays":45,"defaultCreator":""}`},
+		"set":       {StringPtr("user-1"), `{"deleteRecordingAfterDays":45,"defaultCreator":"user-1"}`},
+	} {
+		_, err := client.UpdateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), UpdateStreamLiveInputParameters{
+			LiveInputID:              testLiveInputID,
+			DeleteRecordingAfterDays: 45,
+			DefaultCreator:           tc.defaultCreator,
+		})
+		if assert.NoError(t, err, name) {
+			assert.JSONEq(t, tc.want, body, name)
+		}
+	}
+}
+
+func TestStream_DeleteStreamLiveInput(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method, "Expected method 'DELETE', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": ""}`)
+	})
+
+	err := client.DeleteStreamLiveInput(context.Background(), AccountIdentifier(""), testLiveInputID)
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingAccountID, err)
+	}
+
+	err = client.DeleteStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), "")
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingLiveInputID, err)
+	}
+
+	err = client.DeleteStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	assert.NoError(t, err)
+}
+
+func TestStream_DeleteStreamLiveInput_Audit(t *testing.T) {
+	var events []StreamAuditEvent
+	setup(UsingStreamAuditHook(func(event StreamAuditEvent) {
+		events = append(events, event)
+	}))
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method, "Expected method 'DELETE', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": ""}`)
+	})
+
+	// requests which fail validation are not sent and not audited
+	err := client.DeleteStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), "")
+	assert.Equal(t, ErrMissingLiveInputID, err)
+
+	err = client.DeleteStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []StreamAuditEvent{{
+			Operation:   "DeleteStreamLiveInput",
+			AccountID:   testAccountID,
+			LiveInputID: testLiveInputID,
+		}}, events)
+	}
+}
+
+func TestStream_DeleteStreamLiveInput_IdempotentRetry(t *testing.T) {
+	for name, enabled := range map[string]bool{"enabled": true, "disabled": false} {
+		t.Run(name, func(t *testing.T) {
+			setup(UsingRetryPolicy(1, 0, 0), UsingIdempotentStreamLiveInputDeletes(enabled))
+			defer teardown()
+
+			requestsReceived := 0
+			mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodDelete, r.Method, "Expected method 'DELETE', got %s", r.Method)
+				requestsReceived++
+				w.Header().Set("content-type", "application/json")
+				if requestsReceived == 1 {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"success": false, "errors": [{"code": 10003, "message": "Not Found"}], "messages": [], "result": null}`)
+			})
+
+			err := client.DeleteStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+			assert.Equal(t, 2, requestsReceived)
+			if enabled {
+				assert.NoError(t, err)
+			} else {
+				var notFoundError *NotFoundError
+				assert.ErrorAs(t, err, &notFoundError)
+			}
+		})
+	}
+}
+
+func TestStream_DeleteStreamLiveInput_IdempotentFirstAttempt(t *testing.T) {
+	setup(UsingIdempotentStreamLiveInputDeletes(true))
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10003, "message": "Not Found"}], "messages": [], "result": null}`)
+	})
+
+	err := client.DeleteStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	var notFoundError *NotFoundError
+	assert.ErrorAs(t, err, &notFoundError, "a not found response to the first attempt is still an error")
+}
+
+func TestStream_ListStreamLiveInputVideos(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/videos", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": [
+    {
+      "uid": "%[1]s",
+      "creator": "creator-id_abcde12345",
+      "thumbnail": "https://customer-m033z5x00ks6nunl.cloudflarestream.com/%[1]s/thumbnails/thumbnail.jpg",
+      "thumbnailTimestampPct": 0,
+      "readyToStream": true,
+      "readyToStreamAt": "2014-01-02T02:20:00.123Z",
+      "status": {
+        "state": "ready",
+        "pctComplete": "100.000000",
+        "errorReasonCode": "",
+        "errorReasonText": ""
+      },
+      "meta": {
+        "name": "Live stream 2014-01-02 02:00"
+      },
+      "created": "2014-01-02T02:00:00.123Z",
+      "modified": "2014-01-02T02:21:00.123Z",
+      "size": 0,
+      "preview": "https://customer-m033z5x00ks6nunl.cloudflarestream.com/%[1]s/watch",
+      "allowedOrigins": [],
+      "requireSignedURLs": false,
+      "uploaded": "2014-01-02T02:00:00.123Z",
+      "scheduledDeletion": null,
+      "input": {
+        "width": 1920,
+        "height": 1080
+      },
+      "playback": {
+        "hls": "https://customer-m033z5x00ks6nunl.cloudflarestream.com/%[1]s/manifest/video.m3u8",
+        "dash": "https://customer-m033z5x00ks6nunl.cloudflarestream.com/%[1]s/manifest/video.mpd"
+      },
+      "watermark": null,
+      "liveInput": "%[2]s",
+      "clippedFrom": null,
+      "publicDetails": null,
+      "duration": 1205.5
+    }
+  ]
+}`, testVideoID, testLiveInputID)
+	})
+
+	created := time.Date(2014, time.January, 2, 2, 0, 0, 123000000, time.UTC)
+	readyToStreamAt := time.Date(2014, time.January, 2, 2, 20, 0, 123000000, time.UTC)
+	modified := time.Date(2014, time.January, 2, 2, 21, 0, 123000000, time.UTC)
+	want := []StreamVideo{{
+		UID:             testVideoID,
+		Creator:         "creator-id_abcde12345",
+		Thumbnail:       "https://customer-m033z5x00ks6nunl.cloudflarestream.com/" + testVideoID + "/thumbnails/thumbnail.jpg",
+		ReadyToStream:   true,
+		ReadyToStreamAt: &readyToStreamAt,
+		Status:          StreamVideoStatus{State: "ready", PctComplete: "100.000000"},
+		Meta:            map[string]interface{}{"name": "Live stream 2014-01-02 02:00"},
+		Created:         &created,
+		Modified:        &modified,
+		Preview:         "https://customer-m033z5x00ks6nunl.cloudflarestream.com/" + testVideoID + "/watch",
+		AllowedOrigins:  []string{},
+		Uploaded:        &created,
+		Input:           StreamVideoInput{Width: 1920, Height: 1080},
+		Playback: StreamVideoPlayback{
+			HLS:  "https://customer-m033z5x00ks6nunl.cloudflarestream.com/" + testVideoID + "/manifest/video.m3u8",
+			Dash: "https://customer-m033z5x00ks6nunl.cloudflarestream.com/" + testVideoID + "/manifest/video.mpd",
+		},
+		LiveInput: testLiveInputID,
+		Duration:  1205.5,
+	}}
+
+	_, err := client.ListStreamLiveInputVideos(context.Background(), AccountIdentifier(""), testLiveInputID)
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingAccountID, err)
+	}
+
+	_, err = client.ListStreamLiveInputVideos(context.Background(), AccountIdentifier(testAccountID), "")
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMissingLiveInputID, err)
+	}
+
+	out, err := client.ListStreamLiveInputVideos(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, out)
+	}
+}
+
+func TestStream_DeleteStreamLiveInputVideos(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ids := []string{"11111111111111111111111111111111", "22222222222222222222222222222222", "33333333333333333333333333333333"}
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/videos", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": [
+  {"uid": "%s", "liveInput": "%s", "status": {"state": "ready"}},
+  {"uid": "%s", "liveInput": "%s", "status": {"state": "ready"}},
+  {"uid": "%s", "liveInput": "%s", "status": {"state": "live-inprogress"}}
+]}`, ids[0], testLiveInputID, ids[1], testLiveInputID, ids[2], testLiveInputID)
+	})
+
+	var mu sync.Mutex
+	var deleted []string
+	for _, id := range ids {
+		id := id
+		mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+id, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method, "Expected method 'DELETE', got %s", r.Method)
+			mu.Lock()
+			deleted = append(deleted, id)
+			mu.Unlock()
+			w.Header().Set("content-type", "application/json")
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": ""}`)
+		})
+	}
+
+	var plan []StreamBulkOperation
+	err := client.DeleteStreamLiveInputVideos(context.Background(), AccountIdentifier(testAccountID), testLiveInputID, WithStreamBulkDryRun(&plan))
+	require.NoError(t, err)
+	assert.Equal(t, []StreamBulkOperation{
+		{Method: http.MethodDelete, URI: "/accounts/" + testAccountID + "/stream/" + ids[0], TargetID: ids[0]},
+		{Method: http.MethodDelete, URI: "/accounts/" + testAccountID + "/stream/" + ids[1], TargetID: ids[1]},
+	}, plan)
+	assert.Empty(t, deleted, "a dry run should not delete any videos")
+
+	err = client.DeleteStreamLiveInputVideos(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, ids[:2], deleted)
+
+	err = client.DeleteStreamLiveInputVideos(context.Background(), AccountIdentifier(testAccountID), "")
+	assert.Equal(t, ErrMissingLiveInputID, err)
+}
+
+func TestStream_LiveInputRecordingInheritsSignedURLs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "uid": "%s",
+    "created": "2022-06-30T17:50:00.000000Z",
+    "modified": "2022-06-30T17:50:00.000000Z",
+    "meta": {"name": "signed broadcast"},
+    "recording": {
+      "mode": "automatic",
+      "requireSignedURLs": true,
+      "allowedOrigins": ["example.com"],
+      "timeoutSeconds": 0
+    }
+  }
+}`, testLiveInputID)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/videos", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": [
+    {
+      "uid": "%s",
+      "liveInput": "%s",
+      "readyToStream": true,
+      "requireSignedURLs": true,
+      "allowedOrigins": ["example.com"],
+      "status": {"state": "ready"},
+      "created": "2022-06-30T17:53:12.512033Z"
+    }
+  ]
+}`, testVideoID, testLiveInputID)
+	})
+
+	input, err := client.GetStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	require.NoError(t, err)
+	assert.True(t, input.Recording.InheritsSignedURLs())
+
+	videos, err := client.ListStreamLiveInputVideos(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	require.NoError(t, err)
+	require.Len(t, videos, 1)
+	assert.Equal(t, testLiveInputID, videos[0].LiveInput)
+	assert.Equal(t, input.Recording.InheritsSignedURLs(), videos[0].RequireSignedURLs)
+	assert.Equal(t, input.Recording.AllowedOrigins, videos[0].AllowedOrigins)
+}
+
+func TestStreamLiveInputRecording_InheritsSignedURLs(t *testing.T) {
+	assert.True(t, StreamLiveInputRecording{Mode: StreamLiveInputRecordingModeAutomatic, RequireSignedURLs: true}.InheritsSignedURLs())
+	assert.False(t, StreamLiveInputRecording{Mode: StreamLiveInputRecordingModeAutomatic}.InheritsSignedURLs())
+	assert.False(t, StreamLiveInputRecording{Mode: StreamLiveInputRecordingModeOff, RequireSignedURLs: true}.InheritsSignedURLs())
+	assert.False(t, StreamLiveInputRecording{RequireSignedURLs: true}.InheritsSignedURLs())
+}
+func TestStream_GetCurrentStreamLiveInputRecording(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const liveVideoID = "b236bde30eb07b9d01318940e5fc3eda"
+	live := true
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/videos", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+
+		state := "ready"
+		if live {
+			state = "live-inprogress"
+		}
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": [
+    {"uid": "%s", "liveInput": "%s", "status": {"state": "ready"}},
+    {"uid": "%s", "liveInput": "%s", "status": {"state": "%s"}}
+  ]
+}`, testVideoID, testLiveInputID, liveVideoID, testLiveInputID, state)
+	})
+
+	out, err := client.GetCurrentStreamLiveInputRecording(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, liveVideoID, out.UID)
+		assert.Equal(t, StreamVideoStateLiveInProgress, out.Status.State)
+	}
+
+	live = false
+	_, err = client.GetCurrentStreamLiveInputRecording(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	assert.Equal(t, ErrNoCurrentLiveInputRecording, err)
+}
+
+func TestStream_GetStreamLiveInputViewers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	viewers := 113
+	mux.HandleFunc("/"+testLiveInputID+"/views", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		assert.Empty(t, r.Header.Get("X-Auth-Key"), "credentials should not be sent to the customer domain")
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"liveViewers": %d}`, viewers)
+	})
+
+	_, err := client.GetStreamLiveInputViewers(context.Background(), server.URL, "")
+	assert.Equal(t, ErrMissingLiveInputID, err)
+
+	out, err := client.GetStreamLiveInputViewers(context.Background(), server.URL, testLiveInputID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 113, out)
+	}
+
+	viewers = 0
+	out, err = client.GetStreamLiveInputViewers(context.Background(), server.URL, testLiveInputID)
+	if assert.NoError(t, err) {
+		assert.Zero(t, out)
+	}
+
+	_, err = client.GetStreamLiveInputViewers(context.Background(), server.URL, "a1b2c3d4e5f60718293a4b5c6d7e8f90")
+	assert.ErrorIs(t, err, ErrInvalidStatusCode)
+}
+
+func TestStream_EnsureStreamLiveInputRecordingsReady(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const liveVideoID = "b236bde30eb07b9d01318940e5fc3eda"
+	state := StreamVideoStateLiveInProgress
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/videos", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": [
+    {"uid": "%s", "readyToStream": true, "status": {"state": "ready"}},
+    {"uid": "%s", "readyToStream": false, "status": {"state": "error", "errorReasonCode": "ERR_NON_VIDEO"}},
+    {"uid": "%s", "readyToStream": false, "status": {"state": "%s"}}
+  ]
+}`, testVideoID, "a1b2c3d4e5f60718293a4b5c6d7e8f90", liveVideoID, state)
+	})
+
+	err := client.EnsureStreamLiveInputRecordingsReady(context.Background(), AccountIdentifier(""), testLiveInputID)
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	err = client.EnsureStreamLiveInputRecordingsReady(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	if assert.ErrorIs(t, err, ErrLiveInputRecordingsNotReady) {
+		assert.Contains(t, err.Error(), liveVideoID+" (live-inprogress)")
+		assert.NotContains(t, err.Error(), testVideoID)
+	}
+
+	state = "inprogress"
+	err = client.EnsureStreamLiveInputRecordingsReady(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	assert.ErrorIs(t, err, ErrLiveInputRecordingsNotReady)
+
+	state = "ready"
+	err = client.EnsureStreamLiveInputRecordingsReady(context.Background(), AccountIdentifier(testAccountID), testLiveInputID)
+	assert.NoError(t, err)
+}
+
+// failingRoundTripper fails every request before a response is received.
+type failingRoundTripper struct {
+	err error
+}
+
+func (f failingRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, f.err
+}
+
+func TestStream_ListStreamLiveInputs_TransportError(t *testing.T) {
+	transportErr := errors.New("dial tcp: lookup api.cloudflare.com: no such host")
+	setup(HTTPClient(&http.Client{Transport: failingRoundTripper{err: transportErr}}))
+	defer teardown()
+
+	_, err := client.ListStreamLiveInputs(context.Background(), AccountIdentifier(testAccountID), ListStreamLiveInputsParameters{IncludeCounts: true})
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, transportErr))
+		assert.Contains(t, err.Error(), "ListStreamLiveInputs /accounts/"+testAccountID+"/stream/live_inputs:")
+		assert.NotContains(t, err.Error(), "ListStreamLiveInputs /accounts/"+testAccountID+"/stream/live_inputs?")
+
+		var urlErr *url.Error
+		assert.True(t, errors.As(err, &urlErr))
+	}
+}
+
+func TestStreamLiveInputStatuses_Transitions(t *testing.T) {
+	var statuses StreamLiveInputStatuses
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "current": {
+    "state": "connected",
+    "reason": "connected",
+    "ingestProtocol": "rtmp",
+    "statusEnteredAt": "2014-01-02T04:00:00.000Z",
+    "statusLastSeen": "2014-01-02T04:05:00.000Z"
+  },
+  "history": [
+    {"state": "disconnected", "reason": "client_disconnect", "ingestProtocol": "rtmp", "statusEnteredAt": "2014-01-02T03:00:00.000Z"},
+    {"state": "connected", "reason": "connected", "ingestProtocol": "rtmp", "statusEnteredAt": "2014-01-02T02:00:00.000Z"},
+    {"state": "connected", "reason": "connected", "ingestProtocol": "rtmp", "statusEnteredAt": "2014-01-02T04:00:00.000Z"}
+  ]
+}`), &statuses))
+
+	var states []string
+	var entered []string
+	for _, status := range statuses.Transitions() {
+		states = append(states, status.State)
+		entered = append(entered, status.StatusEnteredAt.Format("15:04"))
+	}
+	assert.Equal(t, []string{"connected", "disconnected", "connected"}, states)
+	assert.Equal(t, []string{"02:00", "03:00", "04:00"}, entered, "the current status should not be repeated")
+
+	if last := statuses.LastConnectedAt(); assert.NotNil(t, last) {
+		assert.Equal(t, time.Date(2014, time.January, 2, 4, 0, 0, 0, time.UTC), *last)
+	}
+
+	disconnectedAt := time.Date(2014, time.January, 2, 5, 0, 0, 0, time.UTC)
+	disconnected := StreamLiveInputStatuses{
+		Current: StreamLiveInputStatus{State: StreamLiveInputStateDisconnected, StatusEnteredAt: &disconnectedAt},
+		History: statuses.History[:2],
+	}
+	assert.Len(t, disconnected.Transitions(), 3)
+	if last := disconnected.LastConnectedAt(); assert.NotNil(t, last) {
+		assert.Equal(t, time.Date(2014, time.January, 2, 2, 0, 0, 0, time.UTC), *last)
+	}
+}
+
+func TestStreamLiveInputStatuses_EmptyHistory(t *testing.T) {
+	var empty StreamLiveInputStatuses
+	assert.Empty(t, empty.Transitions())
+	assert.Nil(t, empty.LastConnectedAt())
+
+	entered := time.Date(2014, time.January, 2, 2, 0, 0, 0, time.UTC)
+	current := StreamLiveInputStatuses{Current: StreamLiveInputStatus{State: StreamLiveInputStateConnected, StatusEnteredAt: &entered}}
+	assert.Equal(t, []StreamLiveInputStatus{current.Current}, current.Transitions())
+	assert.Equal(t, &entered, current.LastConnectedAt())
+}
+
+func TestStreamLiveInputStatus_IsStatusStale(t *testing.T) {
+	now, _ := time.Parse(time.RFC3339, "2023-06-01T12:00:00Z")
+	fresh := now.Add(-10 * time.Second)
+	stale := now.Add(-5 * time.Minute)
+
+	assert.False(t, StreamLiveInputStatus{StatusLastSeen: &fresh}.isStatusStaleAt(now, time.Minute))
+	assert.True(t, StreamLiveInputStatus{StatusLastSeen: &stale}.isStatusStaleAt(now, time.Minute))
+	assert.True(t, StreamLiveInputStatus{}.isStatusStaleAt(now, time.Minute))
+
+	recent := time.Now()
+	assert.False(t, StreamLiveInputStatus{StatusLastSeen: &recent}.IsStatusStale(time.Hour))
+}
+
+func TestStream_SortStreamVideosByCreated(t *testing.T) {
+	t1, _ := time.Parse(time.RFC3339, "2023-01-01T00:00:00Z")
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+
+	videos := []StreamVideo{{UID: "2", Created: &t2}, {UID: "none"}, {UID: "3", Created: &t3}, {UID: "1", Created: &t1}}
+	uids := func() []string {
+		var out []string
+		for _, v := range videos {
+			out = append(out, v.UID)
+		}
+		return out
+	}
+
+	SortStreamVideosByCreated(videos, false)
+	assert.Equal(t, []string{"3", "2", "1", "none"}, uids())
+
+	SortStreamVideosByCreated(videos, true)
+	assert.Equal(t, []string{"1", "2", "3", "none"}, uids())
+}
+
+func TestStream_StreamRecordingReadyLatency(t *testing.T) {
+	ts := func(s string) *time.Time {
+		v, _ := time.Parse(time.RFC3339, s)
+		return &v
+	}
+
+	video := StreamVideo{
+		Created:         ts("2023-01-01T10:00:00Z"),
+		ReadyToStreamAt: ts("2023-01-01T11:00:45Z"),
+	}
+	statuses := &StreamLiveInputStatuses{
+		Current: StreamLiveInputStatus{State: StreamLiveInputStateDisconnected, StatusEnteredAt: ts("2023-01-01T12:00:00Z")},
+		History: []StreamLiveInputStatus{
+			{State: StreamLiveInputStateConnected, StatusEnteredAt: ts("2023-01-01T10:00:00Z")},
+			{State: StreamLiveInputStateDisconnected, StatusEnteredAt: ts("2023-01-01T11:00:00Z")},
+			{State: StreamLiveInputStateDisconnected, StatusEnteredAt: ts("2023-01-01T09:00:00Z")},
+		},
+	}
+
+	latency, ok := StreamRecordingReadyLatency(video, statuses)
+	assert.True(t, ok)
+	assert.Equal(t, 45*time.Second, latency)
+
+	// recording not ready yet
+	_, ok = StreamRecordingReadyLatency(StreamVideo{Created: video.Created}, statuses)
+	assert.False(t, ok)
+
+	// no status history
+	_, ok = StreamRecordingReadyLatency(video, nil)
+	assert.False(t, ok)
+
+	// still connected
+	_, ok = StreamRecordingReadyLatency(video, &StreamLiveInputStatuses{
+		Current: StreamLiveInputStatus{State: StreamLiveInputStateConnected, StatusEnteredAt: ts("2023-01-01T10:00:00Z")},
+	})
+	assert.False(t, ok)
+}
+
+func TestStreamLiveInput_UnmarshalJSONTimestamps(t *testing.T) {
+	want, _ := time.Parse(time.RFC3339, "2014-01-02T02:20:00Z")
+
+	var input StreamLiveInput
+	require.NoError(t, json.Unmarshal([]byte(`{"uid": "`+testLiveInputID+`", "created": "2014-01-02T02:20:00Z", "modified": "2014-01-02 02:20:00"}`), &input))
+	assert.Equal(t, testLiveInputID, input.UID)
+	if assert.NotNil(t, input.Created) && assert.NotNil(t, input.Modified) {
+		assert.True(t, want.Equal(*input.Created))
+		assert.True(t, want.Equal(*input.Modified))
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"uid": "`+testLiveInputID+`", "created": "yesterday"}`), &input))
+	assert.Nil(t, input.Created)
+	assert.Nil(t, input.Modified)
+}
+
+func TestStream_SetStreamLiveInputRecordingMode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	outputs := `[]`
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID+"/outputs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": %s}`, outputs)
+	})
+
+	updates := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs/"+testLiveInputID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		if r.Method == http.MethodPut {
+			updates++
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"recording": {"mode": "off", "allowedOrigins": ["example.com"], "timeoutSeconds": 10}}`, string(b))
+		}
+		fmt.Fprint(w, singleStreamLiveInputResponse)
+	})
+
+	_, err := client.SetStreamLiveInputRecordingMode(context.Background(), AccountIdentifier(testAccountID), SetStreamLiveInputRecordingModeParameters{})
+	assert.Equal(t, ErrMissingLiveInputID, err)
+
+	_, err = client.SetStreamLiveInputRecordingMode(context.Background(), AccountIdentifier(testAccountID), SetStreamLiveInputRecordingModeParameters{
+		LiveInputID: testLiveInputID,
+		Mode:        "sometimes",
+	})
+	assert.ErrorIs(t, err, ErrInvalidLiveInputRecordingMode)
+
+	params := SetStreamLiveInputRecordingModeParameters{
+		LiveInputID:         testLiveInputID,
+		Mode:                StreamLiveInputRecordingModeOff,
+		RequireConfirmation: true,
+	}
+
+	// No outputs, nothing to confirm.
+	_, err = client.SetStreamLiveInputRecordingMode(context.Background(), AccountIdentifier(testAccountID), params)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updates)
+
+	outputs = `[{"uid": "` + testLiveInputOutputID + `", "url": "rtmp://a.rtmp.youtube.com/live2", "enabled": true}]`
+	_, err = client.SetStreamLiveInputRecordingMode(context.Background(), AccountIdentifier(testAccountID), params)
+	assert.ErrorIs(t, err, ErrLiveInputHasOutputs)
+	assert.Equal(t, 1, updates, "live input must not be updated without confirmation")
+
+	params.RequireConfirmation = false
+	out, err := client.SetStreamLiveInputRecordingMode(context.Background(), AccountIdentifier(testAccountID), params)
+	if assert.NoError(t, err) {
+		assert.Equal(t, testStreamLiveInput(), out)
+	}
+	assert.Equal(t, 2, updates)
+}
@@ -0,0 +1,253 @@
+package cloudflare
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// ErrMalformedStreamSignedToken is for when a signed token is not a valid JWT.
+	ErrMalformedStreamSignedToken = errors.New("malformed stream signed token")
+	// ErrInvalidStreamSignedTokenSignature is for when a signed token does not match the verification key.
+	ErrInvalidStreamSignedTokenSignature = errors.New("invalid stream signed token signature")
+	// ErrInvalidStreamSigningKey is for when the private key used to sign tokens locally is not a valid RSA key.
+	ErrInvalidStreamSigningKey = errors.New("invalid stream signing key")
+)
+
+// StreamSignedTokenClaims represents the claims of a signed token used to
+// play a video requiring signed URLs.
+type StreamSignedTokenClaims struct {
+	// KeyID is the ID of the signing key, taken from the "kid" claim or
+	// header.
+	KeyID        string             `json:"kid,omitempty"`
+	Subject      string             `json:"sub,omitempty"`
+	EXP          int64              `json:"exp,omitempty"`
+	NBF          int64              `json:"nbf,omitempty"`
+	Downloadable bool               `json:"downloadable,omitempty"`
+	AccessRules  []StreamAccessRule `json:"accessRules,omitempty"`
+}
+
+// ExpiresAt returns the time after which the token is rejected, or the zero
+// time when the token has no expiry.
+func (c StreamSignedTokenClaims) ExpiresAt() time.Time {
+	if c.EXP == 0 {
+		return time.Time{}
+	}
+	return time.Unix(c.EXP, 0)
+}
+
+// NotBefore returns the time before which the token is rejected, or the zero
+// time when the token is valid immediately.
+func (c StreamSignedTokenClaims) NotBefore() time.Time {
+	if c.NBF == 0 {
+		return time.Time{}
+	}
+	return time.Unix(c.NBF, 0)
+}
+
+// defaultStreamSignedTokenExpiry is the lifetime of locally signed tokens
+// without an expiry, matching the default of the API.
+const defaultStreamSignedTokenExpiry = time.Hour
+
+type streamSignedTokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// ParseStreamSignedToken decodes the claims of a signed token, such as one
+// returned by StreamCreateSignedURL. The signature is only verified when key
+// is not nil; tokens are signed with RS256 using the private half of a Stream
+// signing key.
+//
+// Expiry is not checked so that expired tokens can still be inspected, use
+// ExpiresAt and NotBefore on the returned claims instead.
+func ParseStreamSignedToken(token string, key *rsa.PublicKey) (StreamSignedTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return StreamSignedTokenClaims{}, fmt.Errorf("%w: expected 3 segments, got %d", ErrMalformedStreamSignedToken, len(parts))
+	}
+
+	var header streamSignedTokenHeader
+	if err := decodeStreamSignedTokenSegment(parts[0], &header); err != nil {
+		return StreamSignedTokenClaims{}, fmt.Errorf("%w: header: %s", ErrMalformedStreamSignedToken, err)
+	}
+
+	var claims StreamSignedTokenClaims
+	if err := decodeStreamSignedTokenSegment(parts[1], &claims); err != nil {
+		return StreamSignedTokenClaims{}, fmt.Errorf("%w: claims: %s", ErrMalformedStreamSignedToken, err)
+	}
+
+	if claims.KeyID == "" {
+		claims.KeyID = header.Kid
+	}
+
+	if key == nil {
+		return claims, nil
+	}
+
+	if header.Alg != "RS256" {
+		return claims, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidStreamSignedTokenSignature, header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return claims, fmt.Errorf("%w: signature: %s", ErrMalformedStreamSignedToken, err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return claims, ErrInvalidStreamSignedTokenSignature
+	}
+
+	return claims, nil
+}
+
+// SignStreamToken signs claims locally with the private half of a Stream
+// signing key, creating a token without an API request. claims.KeyID must be
+// the ID of the signing key and claims.Subject the UID of the video.
+func SignStreamToken(claims StreamSignedTokenClaims, key *rsa.PrivateKey) (string, error) {
+	if claims.KeyID == "" {
+		return "", ErrMissingSigningKeyID
+	}
+
+	if claims.Subject == "" {
+		return "", ErrMissingVideoID
+	}
+
+	for _, rule := range claims.AccessRules {
+		if err := rule.validate(); err != nil {
+			return "", err
+		}
+	}
+
+	header, err := json.Marshal(streamSignedTokenHeader{Alg: "RS256", Kid: claims.KeyID})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// GenerateStreamSignedTokensParameters are the parameters used when
+// generating signed tokens for multiple videos.
+type GenerateStreamSignedTokensParameters struct {
+	// KeyID and Key are the ID and private key of a Stream signing key. When
+	// Key is set, tokens are signed locally using SignStreamToken rather than
+	// requested from the API for every video.
+	KeyID string
+	Key   *rsa.PrivateKey
+
+	EXP          int
+	NBF          int
+	Downloadable bool
+	AccessRules  []StreamAccessRule
+}
+
+// GenerateStreamSignedTokens creates a signed token for each video, for
+// example to play every video of a playlist, and returns them keyed by video
+// UID. When EXP is not set, the expiry configured with
+// UsingStreamSignedURLExpiry is applied, or one hour for tokens signed
+// locally, shortened to the maximum set with UsingStreamSignedURLMaxExpiry.
+// ErrStreamSignedURLExpiryTooLong is returned without creating any tokens when
+// an explicit EXP is later than that maximum allows. When signing locally, a
+// missing KeyID or an invalid Key is likewise returned as a single error. The
+// errors of videos for which no token could be created are returned as
+// StreamBulkErrors keyed by video UID.
+func (api *API) GenerateStreamSignedTokens(ctx context.Context, rc *ResourceContainer, videoIDs []string, params GenerateStreamSignedTokensParameters, opts ...StreamBulkOption) (map[string]string, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return map[string]string{}, err
+	}
+
+	if params.Key != nil {
+		if params.KeyID == "" {
+			return map[string]string{}, ErrMissingSigningKeyID
+		}
+		if err := params.Key.Validate(); err != nil {
+			return map[string]string{}, fmt.Errorf("%w: %s", ErrInvalidStreamSigningKey, err)
+		}
+	}
+	for _, rule := range params.AccessRules {
+		if err := rule.validate(); err != nil {
+			return map[string]string{}, err
+		}
+	}
+
+	expiry := api.streamSignedURLExpiry
+	if params.Key != nil && expiry <= 0 {
+		expiry = defaultStreamSignedTokenExpiry
+	}
+
+	exp, err := api.limitStreamSignedURLExpiry(params.EXP, expiry)
+	if err != nil {
+		return map[string]string{}, err
+	}
+	params.EXP = exp
+
+	var mu sync.Mutex
+	tokens := make(map[string]string, len(videoIDs))
+
+	err = streamBulk(ctx, videoIDs, func(ctx context.Context, id string) error {
+		var token string
+		var err error
+		if params.Key != nil {
+			token, err = SignStreamToken(StreamSignedTokenClaims{
+				KeyID:        params.KeyID,
+				Subject:      id,
+				EXP:          int64(params.EXP),
+				NBF:          int64(params.NBF),
+				Downloadable: params.Downloadable,
+				AccessRules:  params.AccessRules,
+			}, params.Key)
+		} else {
+			token, err = api.StreamCreateSignedURL(ctx, StreamSignedURLParameters{
+				AccountID:    rc.Identifier,
+				VideoID:      id,
+				EXP:          params.EXP,
+				NBF:          params.NBF,
+				Downloadable: params.Downloadable,
+				AccessRules:  params.AccessRules,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		tokens[id] = token
+		mu.Unlock()
+		return nil
+	}, opts...)
+
+	return tokens, err
+}
+
+func decodeStreamSignedTokenSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
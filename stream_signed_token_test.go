@@ -0,0 +1,178 @@
+package cloudflare
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStreamSignedToken has the header {"alg":"RS256","kid":"e9f5ba46d0c8fdbb9d4c3b2b0d4cbfbf"}
+// and the claims {"sub":"ea95132c15732412d22c1476fa83f27a","exp":1700000000,"nbf":1690000000,"downloadable":true}.
+const testStreamSignedToken = "eyJhbGciOiJSUzI1NiIsImtpZCI6ImU5ZjViYTQ2ZDBjOGZkYmI5ZDRjM2IyYjBkNGNiZmJmIn0." +
+	"eyJzdWIiOiJlYTk1MTMyYzE1NzMyNDEyZDIyYzE0NzZmYTgzZjI3YSIsImV4cCI6MTcwMDAwMDAwMCwibmJmIjoxNjkwMDAwMDAwLCJkb3dubG9hZGFibGUiOnRydWV9." +
+	"c2lnbmF0dXJl"
+
+func TestParseStreamSignedToken(t *testing.T) {
+	claims, err := ParseStreamSignedToken(testStreamSignedToken, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, StreamSignedTokenClaims{
+			KeyID:        "e9f5ba46d0c8fdbb9d4c3b2b0d4cbfbf",
+			Subject:      "ea95132c15732412d22c1476fa83f27a",
+			EXP:          1700000000,
+			NBF:          1690000000,
+			Downloadable: true,
+		}, claims)
+		assert.Equal(t, time.Unix(1700000000, 0), claims.ExpiresAt())
+		assert.Equal(t, time.Unix(1690000000, 0), claims.NotBefore())
+	}
+}
+
+func TestParseStreamSignedToken_Malformed(t *testing.T) {
+	for _, token := range []string{
+		"",
+		"garbage",
+		"a.b",
+		"!!!.eyJzdWIiOiJ4In0.c2ln",
+		"eyJhbGciOiJSUzI1NiJ9.bm90IGpzb24.c2ln",
+	} {
+		_, err := ParseStreamSignedToken(token, nil)
+		assert.True(t, errors.Is(err, ErrMalformedStreamSignedToken), "token %q", token)
+	}
+}
+
+func TestParseStreamSignedToken_Verify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	signingInput := "eyJhbGciOiJSUzI1NiIsImtpZCI6ImU5ZjViYTQ2ZDBjOGZkYmI5ZDRjM2IyYjBkNGNiZmJmIn0." +
+		"eyJzdWIiOiJlYTk1MTMyYzE1NzMyNDEyZDIyYzE0NzZmYTgzZjI3YSIsImV4cCI6MTcwMDAwMDAwMCwibmJmIjoxNjkwMDAwMDAwLCJkb3dubG9hZGFibGUiOnRydWV9"
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+
+	claims, err := ParseStreamSignedToken(token, &key.PublicKey)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ea95132c15732412d22c1476fa83f27a", claims.Subject)
+	}
+
+	_, err = ParseStreamSignedToken(testStreamSignedToken, &key.PublicKey)
+	assert.Equal(t, ErrInvalidStreamSignedTokenSignature, err)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, err = ParseStreamSignedToken(token, &other.PublicKey)
+	assert.Equal(t, ErrInvalidStreamSignedTokenSignature, err)
+}
+
+func TestSignStreamToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	claims := StreamSignedTokenClaims{
+		KeyID:       "e9f5ba46d0c8fdbb9d4c3b2b0d4cbfbf",
+		Subject:     "ea95132c15732412d22c1476fa83f27a",
+		EXP:         1700000000,
+		AccessRules: []StreamAccessRule{{Type: "ip.geoip.country", Country: []string{"US"}, Action: "allow"}},
+	}
+	token, err := SignStreamToken(claims, key)
+	require.NoError(t, err)
+
+	parsed, err := ParseStreamSignedToken(token, &key.PublicKey)
+	if assert.NoError(t, err) {
+		assert.Equal(t, claims, parsed)
+	}
+
+	_, err = SignStreamToken(StreamSignedTokenClaims{Subject: claims.Subject}, key)
+	assert.Equal(t, ErrMissingSigningKeyID, err)
+
+	_, err = SignStreamToken(StreamSignedTokenClaims{KeyID: claims.KeyID}, key)
+	assert.Equal(t, ErrMissingVideoID, err)
+
+	_, err = SignStreamToken(StreamSignedTokenClaims{KeyID: claims.KeyID, Subject: claims.Subject, AccessRules: []StreamAccessRule{{Type: "any"}}}, key)
+	assert.ErrorIs(t, err, ErrInvalidStreamAccessRule)
+}
+
+func TestStream_GenerateStreamSignedTokens_Local(t *testing.T) {
+	setup()
+	defer teardown()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ids := []string{"11111111111111111111111111111111", "22222222222222222222222222222222", "33333333333333333333333333333333"}
+	params := GenerateStreamSignedTokensParameters{
+		KeyID:        "e9f5ba46d0c8fdbb9d4c3b2b0d4cbfbf",
+		Key:          key,
+		Downloadable: true,
+	}
+
+	_, err = client.GenerateStreamSignedTokens(context.Background(), AccountIdentifier(""), ids, params)
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	before := time.Now().Add(time.Hour).Unix()
+	tokens, err := client.GenerateStreamSignedTokens(context.Background(), AccountIdentifier(testAccountID), ids, params)
+	require.NoError(t, err)
+	require.Len(t, tokens, len(ids))
+
+	for _, id := range ids {
+		claims, err := ParseStreamSignedToken(tokens[id], &key.PublicKey)
+		if assert.NoError(t, err, id) {
+			assert.Equal(t, id, claims.Subject)
+			assert.Equal(t, params.KeyID, claims.KeyID)
+			assert.True(t, claims.Downloadable)
+			assert.GreaterOrEqual(t, claims.EXP, before)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tokens, err = client.GenerateStreamSignedTokens(ctx, AccountIdentifier(testAccountID), ids, params)
+	assert.Empty(t, tokens)
+
+	var bulkErrs StreamBulkErrors
+	require.True(t, errors.As(err, &bulkErrs))
+	for _, id := range ids {
+		assert.True(t, errors.Is(bulkErrs[id], context.Canceled))
+	}
+}
+
+func TestStream_GenerateStreamSignedTokens_LocalValidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ids := []string{"11111111111111111111111111111111", "22222222222222222222222222222222"}
+
+	// The parameters are checked once rather than failing every video.
+	tokens, err := client.GenerateStreamSignedTokens(context.Background(), AccountIdentifier(testAccountID), ids, GenerateStreamSignedTokensParameters{Key: key})
+	assert.Equal(t, ErrMissingSigningKeyID, err)
+	assert.Empty(t, tokens)
+
+	invalid := *key
+	invalid.D = big.NewInt(1)
+	_, err = client.GenerateStreamSignedTokens(context.Background(), AccountIdentifier(testAccountID), ids, GenerateStreamSignedTokensParameters{KeyID: "e9f5ba46d0c8fdbb9d4c3b2b0d4cbfbf", Key: &invalid})
+	assert.ErrorIs(t, err, ErrInvalidStreamSigningKey)
+
+	_, err = client.GenerateStreamSignedTokens(context.Background(), AccountIdentifier(testAccountID), ids, GenerateStreamSignedTokensParameters{
+		KeyID:       "e9f5ba46d0c8fdbb9d4c3b2b0d4cbfbf",
+		Key:         key,
+		AccessRules: []StreamAccessRule{{Type: "any"}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidStreamAccessRule)
+
+	var bulkErrs StreamBulkErrors
+	assert.False(t, errors.As(err, &bulkErrs))
+}
@@ -0,0 +1,106 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// ErrMissingSigningKeyID is for when a signing key ID is required but missing.
+	ErrMissingSigningKeyID = errors.New("required signing key id missing")
+)
+
+// StreamSigningKey represents a key used to sign Stream tokens locally.
+// Created is nil if the API did not return a creation time.
+type StreamSigningKey struct {
+	ID      string     `json:"id,omitempty"`
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// StreamSigningKeysResponse represents an API response of listing signing
+// keys.
+type StreamSigningKeysResponse struct {
+	Response
+	Result []StreamSigningKey `json:"result,omitempty"`
+}
+
+// ListStreamSigningKeys lists the signing keys of an account.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-signing-keys-list-signing-keys
+func (api *API) ListStreamSigningKeys(ctx context.Context, rc *ResourceContainer) ([]StreamSigningKey, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return []StreamSigningKey{}, err
+	}
+
+	uri := streamBasePath(rc.Identifier) + "/keys"
+	res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return []StreamSigningKey{}, wrapStreamTransportError("ListStreamSigningKeys", uri, err)
+	}
+
+	var r StreamSigningKeysResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return []StreamSigningKey{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	return r.Result, nil
+}
+
+// DeleteStreamSigningKey deletes a signing key. Tokens signed with the key
+// are no longer accepted.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-signing-keys-delete-signing-keys
+func (api *API) DeleteStreamSigningKey(ctx context.Context, rc *ResourceContainer, keyID string) error {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return err
+	}
+
+	if keyID == "" {
+		return ErrMissingSigningKeyID
+	}
+
+	uri := fmt.Sprintf("%s/keys/%s", streamBasePath(rc.Identifier), keyID)
+	_, err := api.makeRequestContext(ctx, http.MethodDelete, uri, nil)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "DeleteStreamSigningKey", AccountID: rc.Identifier, SigningKeyID: keyID}, err)
+	if err != nil {
+		return wrapStreamTransportError("DeleteStreamSigningKey", uri, err)
+	}
+	return nil
+}
+
+// DeleteStreamSigningKeys deletes multiple signing keys concurrently. The
+// errors of keys which could not be deleted are returned as StreamBulkErrors
+// keyed by key ID.
+func (api *API) DeleteStreamSigningKeys(ctx context.Context, rc *ResourceContainer, keyIDs []string, opts ...StreamBulkOption) error {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return err
+	}
+
+	op := func(id string) StreamBulkOperation {
+		return StreamBulkOperation{Method: http.MethodDelete, URI: fmt.Sprintf("%s/keys/%s", streamBasePath(rc.Identifier), id), TargetID: id}
+	}
+	return streamBulkChange(ctx, keyIDs, op, func(ctx context.Context, id string) error {
+		return api.DeleteStreamSigningKey(ctx, rc, id)
+	}, opts...)
+}
+
+// StreamSigningKeysOlderThan returns the keys created more than maxAge ago,
+// for example to find keys which are due to be rotated. Keys without a
+// Created timestamp are skipped as their age is unknown.
+func StreamSigningKeysOlderThan(keys []StreamSigningKey, maxAge time.Duration) []StreamSigningKey {
+	return streamSigningKeysOlderThanAt(keys, time.Now(), maxAge)
+}
+
+func streamSigningKeysOlderThanAt(keys []StreamSigningKey, now time.Time, maxAge time.Duration) []StreamSigningKey {
+	old := []StreamSigningKey{}
+	for _, key := range keys {
+		if key.Created != nil && now.Sub(*key.Created) > maxAge {
+			old = append(old, key)
+		}
+	}
+	return old
+}
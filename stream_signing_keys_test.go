@@ -0,0 +1,133 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream_ListStreamSigningKeys(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/keys", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": [
+    {"id": "e9db990a82666dd571c77f944a5c5c8d", "created": "2014-01-02T02:20:00Z"},
+    {"id": "8f926b2b66c4c7e0c1e5ad1f4a8e3b29"}
+  ]
+}`)
+	})
+
+	_, err := client.ListStreamSigningKeys(context.Background(), AccountIdentifier(""))
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	created, _ := time.Parse(time.RFC3339, "2014-01-02T02:20:00Z")
+	want := []StreamSigningKey{
+		{ID: "e9db990a82666dd571c77f944a5c5c8d", Created: &created},
+		{ID: "8f926b2b66c4c7e0c1e5ad1f4a8e3b29"},
+	}
+
+	out, err := client.ListStreamSigningKeys(context.Background(), AccountIdentifier(testAccountID))
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, out)
+	}
+}
+
+func TestStreamSigningKeysOlderThan(t *testing.T) {
+	now, _ := time.Parse(time.RFC3339, "2023-06-01T12:00:00Z")
+	old := now.Add(-100 * 24 * time.Hour)
+	recent := now.Add(-10 * 24 * time.Hour)
+
+	keys := []StreamSigningKey{
+		{ID: "old", Created: &old},
+		{ID: "recent", Created: &recent},
+		{ID: "unknown"},
+	}
+
+	assert.Equal(t, []StreamSigningKey{{ID: "old", Created: &old}}, streamSigningKeysOlderThanAt(keys, now, 90*24*time.Hour))
+	assert.Len(t, streamSigningKeysOlderThanAt(keys, now, 5*24*time.Hour), 2)
+	assert.Empty(t, streamSigningKeysOlderThanAt(keys, now, 365*24*time.Hour))
+	assert.Empty(t, StreamSigningKeysOlderThan(nil, time.Hour))
+}
+
+func TestStream_DeleteStreamSigningKeys(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ids := []string{"e9db990a82666dd571c77f944a5c5c8d", "8f926b2b66c4c7e0c1e5ad1f4a8e3b29", "0c1e5ad1f4a8e3b298f926b2b66c4c7e"}
+
+	var mu sync.Mutex
+	deleted := []string{}
+	for _, id := range ids[:2] {
+		id := id
+		mux.HandleFunc("/accounts/"+testAccountID+"/stream/keys/"+id, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method, "Expected method 'DELETE', got %s", r.Method)
+			mu.Lock()
+			deleted = append(deleted, id)
+			mu.Unlock()
+			w.Header().Set("content-type", "application/json")
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": "ok"}`)
+		})
+	}
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/keys/"+ids[2], func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10005, "message": "key not found"}], "messages": [], "result": null}`)
+	})
+
+	err := client.DeleteStreamSigningKeys(context.Background(), AccountIdentifier(""), ids)
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	err = client.DeleteStreamSigningKeys(context.Background(), AccountIdentifier(testAccountID), ids)
+	assert.ElementsMatch(t, ids[:2], deleted)
+
+	var bulkErrs StreamBulkErrors
+	require.True(t, errors.As(err, &bulkErrs))
+	assert.Len(t, bulkErrs, 1)
+
+	var notFound *NotFoundError
+	assert.True(t, errors.As(bulkErrs[ids[2]], &notFound))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = client.DeleteStreamSigningKeys(ctx, AccountIdentifier(testAccountID), ids[:1])
+	require.True(t, errors.As(err, &bulkErrs))
+	assert.True(t, errors.Is(bulkErrs[ids[0]], context.Canceled))
+}
+
+func TestStream_DeleteStreamSigningKey_Audit(t *testing.T) {
+	var events []StreamAuditEvent
+	setup(UsingStreamAuditHook(func(event StreamAuditEvent) {
+		events = append(events, event)
+	}))
+	defer teardown()
+
+	const keyID = "e9db990a82666dd571c77f944a5c5c8d"
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/keys/"+keyID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method, "Expected method 'DELETE', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": "ok"}`)
+	})
+
+	err := client.DeleteStreamSigningKey(context.Background(), AccountIdentifier(testAccountID), keyID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []StreamAuditEvent{{
+			Operation:    "DeleteStreamSigningKey",
+			AccountID:    testAccountID,
+			SigningKeyID: keyID,
+		}}, events)
+	}
+}
@@ -2,11 +2,15 @@ package cloudflare
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"testing"
 	"time"
 
+	"github.com/goccy/go-json"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -177,6 +181,36 @@ func TestStream_StreamUploadFromURL(t *testing.T) {
 	}
 }
 
+func TestStream_StreamUploadFromURL_DownloadedFrom(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/copy", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "uid": "ea95132c15732412d22c1476fa83f27a",
+    "downloadedFrom": "https://example.com/myvideo.mp4"
+  }
+}`)
+	})
+
+	out, err := client.StreamUploadFromURL(context.Background(), StreamUploadFromURLParameters{
+		AccountID: testAccountID,
+		URL:       "https://example.com/myvideo.mp4",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "https://example.com/myvideo.mp4", out.DownloadedFrom)
+	}
+
+	// videos which were not copied have no source
+	assert.Empty(t, TestVideoStruct.DownloadedFrom)
+}
+
 func TestStream_UploadVideoFile(t *testing.T) {
 	setup()
 	defer teardown()
@@ -216,6 +250,57 @@ func TestStream_UploadVideoFile(t *testing.T) {
 	}
 }
 
+func TestStream_UploadVideoFile_MaxUploadBufferSize(t *testing.T) {
+	file, err := os.ReadFile("stream_test.go")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		maxUploadBufferSize int64
+		wantRequests        int
+		wantStreamed        bool
+	}{
+		"buffered below the threshold": {maxUploadBufferSize: int64(len(file)), wantRequests: 2},
+		"streamed above the threshold": {maxUploadBufferSize: int64(len(file)) - 1, wantRequests: 1, wantStreamed: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			setup(UsingRetryPolicy(1, 0, 0), UsingMaxUploadBufferSize(tc.maxUploadBufferSize))
+			defer teardown()
+
+			var requests int
+			mux.HandleFunc("/accounts/"+testAccountID+"/stream", func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				assert.Equal(t, tc.wantStreamed, r.ContentLength == -1, "unexpected Content-Length %d", r.ContentLength)
+
+				formFile, _, err := r.FormFile("file")
+				if assert.NoError(t, err) {
+					got, _ := io.ReadAll(formFile)
+					assert.Equal(t, file, got)
+				}
+
+				if requests == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Header().Set("content-type", "application/json")
+				fmt.Fprint(w, singleStreamResponse)
+			})
+
+			_, err := client.StreamUploadVideoFile(context.Background(), StreamUploadFileParameters{
+				AccountID: testAccountID,
+				FilePath:  "stream_test.go",
+			})
+			if tc.wantStreamed {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantRequests, requests)
+		})
+	}
+}
+
 func TestStream_CreateVideoDirectURL(t *testing.T) {
 	setup()
 	defer teardown()
@@ -303,6 +388,267 @@ func TestStream_CreateVideoDirectURL(t *testing.T) {
 	}
 }
 
+func TestStream_CreateVideoDirectURL_Creator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/direct_upload", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "creator-id_abcde12345", body["creator"])
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "ea95132c15732412d22c1476fa83f27a"}}`)
+	})
+
+	_, err := client.StreamCreateVideoDirectURL(context.Background(), StreamCreateVideoParameters{
+		AccountID:          testAccountID,
+		MaxDurationSeconds: 300,
+		Creator:            "creator-id_abcde12345",
+	})
+	assert.NoError(t, err)
+}
+
+func TestStream_CreateVideo_NormalizesMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var metas []map[string]interface{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Meta map[string]interface{} `json:"meta"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		metas = append(metas, body.Meta)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "`+testVideoID+`"}}`)
+	}
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/copy", handler)
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/direct_upload", handler)
+
+	meta := map[string]interface{}{"name": "video.mp4", "season": 2, "featured": true}
+	want := map[string]interface{}{"name": "video.mp4", "season": "2", "featured": "true"}
+
+	_, err := client.StreamUploadFromURL(context.Background(), StreamUploadFromURLParameters{
+		AccountID: testAccountID,
+		URL:       "https://example.com/video.mp4",
+		Meta:      meta,
+	})
+	require.NoError(t, err)
+
+	_, err = client.StreamCreateVideoDirectURL(context.Background(), StreamCreateVideoParameters{
+		AccountID:          testAccountID,
+		MaxDurationSeconds: 300,
+		Meta:               meta,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{want, want}, metas)
+
+	nested := map[string]interface{}{"tags": map[string]interface{}{"genre": "news"}}
+	_, err = client.StreamUploadFromURL(context.Background(), StreamUploadFromURLParameters{
+		AccountID: testAccountID,
+		URL:       "https://example.com/video.mp4",
+		Meta:      nested,
+	})
+	assert.ErrorIs(t, err, ErrInvalidStreamMeta)
+
+	_, err = client.StreamCreateVideoDirectURL(context.Background(), StreamCreateVideoParameters{
+		AccountID:          testAccountID,
+		MaxDurationSeconds: 300,
+		Meta:               nested,
+	})
+	assert.ErrorIs(t, err, ErrInvalidStreamMeta)
+	assert.Len(t, metas, 2, "invalid meta must not be sent")
+}
+
+func TestStream_UpdateStreamVideo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"creator":"creator-id_abcde12345","requireSignedURLs":false}`, string(b))
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamResponse)
+	})
+
+	_, err := client.UpdateStreamVideo(context.Background(), AccountIdentifier(""), UpdateStreamVideoParameters{})
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	_, err = client.UpdateStreamVideo(context.Background(), AccountIdentifier(testAccountID), UpdateStreamVideoParameters{})
+	assert.Equal(t, ErrMissingVideoID, err)
+
+	out, err := client.UpdateStreamVideo(context.Background(), AccountIdentifier(testAccountID), UpdateStreamVideoParameters{
+		VideoID:           testVideoID,
+		Creator:           "creator-id_abcde12345",
+		RequireSignedURLs: BoolPtr(false),
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, TestVideoStruct, out)
+	}
+}
+
+func TestStream_EnsureStreamVideoCreator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	updates := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, singleStreamResponse)
+		case http.MethodPost:
+			updates++
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "tenant-2", body["creator"])
+			assert.Equal(t, map[string]interface{}{"name": "My First Stream Video"}, body["meta"])
+			assert.Equal(t, []interface{}{"example.com"}, body["allowedOrigins"])
+			assert.Equal(t, true, body["requireSignedURLs"])
+			assert.Equal(t, 0.529241, body["thumbnailTimestampPct"])
+			assert.Equal(t, "2014-01-02T02:20:00Z", body["scheduledDeletion"])
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "creator": "tenant-2"}}`, testVideoID)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	// creator already matches
+	out, err := client.EnsureStreamVideoCreator(context.Background(), AccountIdentifier(testAccountID), testVideoID, "creator-id_abcde12345")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "creator-id_abcde12345", out.Creator)
+		assert.Equal(t, 0, updates)
+	}
+
+	// creator is fixed
+	out, err = client.EnsureStreamVideoCreator(context.Background(), AccountIdentifier(testAccountID), testVideoID, "tenant-2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "tenant-2", out.Creator)
+		assert.Equal(t, 1, updates)
+	}
+}
+
+func TestStream_WaitForStreamVideoReady(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		polls++
+
+		w.Header().Set("content-type", "application/json")
+		if polls < 3 {
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "readyToStream": false, "status": {"state": "inprogress", "pctComplete": "50.0"}}}`, testVideoID)
+			return
+		}
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "readyToStream": true, "status": {"state": "ready"}}}`, testVideoID)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/failed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "failed", "status": {"state": "error", "errorReasonCode": "ERR_NON_VIDEO", "errorReasonText": "The file was not recognized as a valid video file."}}}`)
+	})
+	readyPolls := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/ready", func(w http.ResponseWriter, r *http.Request) {
+		readyPolls++
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "ready", "readyToStream": false, "status": {"state": "ready"}}}`)
+	})
+
+	_, err := client.WaitForStreamVideoReady(context.Background(), AccountIdentifier(""), testVideoID, time.Millisecond)
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	out, err := client.WaitForStreamVideoReady(context.Background(), AccountIdentifier(testAccountID), testVideoID, time.Millisecond)
+	if assert.NoError(t, err) {
+		assert.True(t, out.ReadyToStream)
+		assert.Equal(t, testVideoID, out.UID)
+		assert.Equal(t, 3, polls)
+	}
+
+	_, err = client.WaitForStreamVideoReady(context.Background(), AccountIdentifier(testAccountID), "failed", time.Millisecond)
+	assert.True(t, errors.Is(err, ErrStreamVideoProcessingFailed))
+
+	// A ready status is enough, as for PlaybackURLs.
+	out, err = client.WaitForStreamVideoReady(context.Background(), AccountIdentifier(testAccountID), "ready", time.Millisecond)
+	if assert.NoError(t, err) {
+		assert.True(t, out.IsReady())
+		assert.Equal(t, 1, readyPolls)
+	}
+}
+
+func TestStream_WaitForStreamVideoReadyWithProgress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	responses := []string{
+		`{"state": "downloading", "pctComplete": "12.5"}`,
+		`{"state": "inprogress"}`,
+		`{"state": "inprogress", "pctComplete": "60.000000"}`,
+		`{"state": "ready", "pctComplete": "100.000000"}`,
+	}
+	polls := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		status := responses[polls]
+		polls++
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "readyToStream": %t, "status": %s}}`, testVideoID, polls == len(responses), status)
+	})
+
+	var reported []float64
+	out, err := client.WaitForStreamVideoReadyWithProgress(context.Background(), AccountIdentifier(testAccountID), testVideoID, time.Millisecond, func(pctComplete float64) {
+		reported = append(reported, pctComplete)
+	})
+	if assert.NoError(t, err) {
+		assert.True(t, out.ReadyToStream)
+		assert.Equal(t, []float64{12.5, 60, 100}, reported)
+	}
+}
+
+func TestStream_GetClipSource(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const clipID = "e2a4e1ab9b45f2c3b9a3d7f0e2c7a1b6"
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+clipID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "clippedFromVideoUID": "%s"}}`, clipID, testVideoID)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, singleStreamResponse)
+	})
+
+	assert.False(t, TestVideoStruct.IsClip())
+	assert.Empty(t, TestVideoStruct.SourceVideoUID())
+
+	clip := StreamVideo{UID: clipID, ClippedFromVideoUID: testVideoID}
+	assert.True(t, clip.IsClip())
+	assert.Equal(t, testVideoID, clip.SourceVideoUID())
+
+	_, err := client.GetClipSource(context.Background(), AccountIdentifier(""), clipID)
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	out, err := client.GetClipSource(context.Background(), AccountIdentifier(testAccountID), clipID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, TestVideoStruct, out)
+	}
+
+	_, err = client.GetClipSource(context.Background(), AccountIdentifier(testAccountID), testVideoID)
+	assert.Equal(t, ErrStreamVideoNotClip, err)
+}
+
 func TestStream_ListVideos(t *testing.T) {
 	setup()
 	defer teardown()
@@ -390,6 +736,22 @@ func TestStream_ListVideos(t *testing.T) {
 	}
 }
 
+func TestStream_InvalidAccountID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/example.com/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent for an invalid account ID")
+	})
+
+	_, err := client.StreamGetVideo(context.Background(), StreamParameters{AccountID: "example.com", VideoID: testVideoID})
+	assert.ErrorIs(t, err, ErrInvalidAccountID)
+	assert.Contains(t, err.Error(), `"example.com"`)
+
+	_, err = client.GetStreamLiveInput(context.Background(), AccountIdentifier("example.com"), testLiveInputID)
+	assert.ErrorIs(t, err, ErrInvalidAccountID)
+}
+
 func TestStream_GetVideo(t *testing.T) {
 	setup()
 	defer teardown()
@@ -550,6 +912,166 @@ func TestStream_CreateSignedURL(t *testing.T) {
 	if assert.NoError(t, err) {
 		assert.Equal(t, want, out, "structs not equal")
 	}
+
+	input.AccessRules = []StreamAccessRule{
+		{Type: "ip.geoip.country", Country: []string{"US", "MX"}, Action: "allow"},
+		{Type: "ip.src", IP: []string{"93.184.216.0/24"}, Action: "allow"},
+		{Type: "any", Action: "block"},
+	}
+	out, err = client.StreamCreateSignedURL(context.Background(), input)
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, out)
+	}
+}
+
+func TestStream_CreateSignedURL_Downloadable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body map[string]interface{}
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		body = nil
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"token": "token"}}`)
+	})
+
+	params := StreamSignedURLParameters{AccountID: testAccountID, VideoID: testVideoID}
+	_, err := client.StreamCreateSignedURL(context.Background(), params)
+	require.NoError(t, err)
+	assert.NotContains(t, body, "downloadable")
+
+	params.Downloadable = true
+	_, err = client.StreamCreateSignedURL(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, true, body["downloadable"])
+}
+
+func TestStream_CreateSignedURL_DefaultExpiry(t *testing.T) {
+	setup(UsingStreamSignedURLExpiry(time.Hour))
+	defer teardown()
+
+	var params StreamSignedURLParameters
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		params = StreamSignedURLParameters{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"token": "token"}}`)
+	})
+
+	before := time.Now().Add(time.Hour).Unix()
+	_, err := client.StreamCreateSignedURL(context.Background(), StreamSignedURLParameters{AccountID: testAccountID, VideoID: testVideoID})
+	require.NoError(t, err)
+	after := time.Now().Add(time.Hour).Unix()
+	assert.GreaterOrEqual(t, int64(params.EXP), before)
+	assert.LessOrEqual(t, int64(params.EXP), after)
+
+	_, err = client.StreamCreateSignedURL(context.Background(), StreamSignedURLParameters{AccountID: testAccountID, VideoID: testVideoID, EXP: 1537460365})
+	require.NoError(t, err)
+	assert.Equal(t, 1537460365, params.EXP, "an explicit expiry must not be replaced")
+}
+
+func TestStream_CreateSignedURL_MaxExpiry(t *testing.T) {
+	setup(UsingStreamSignedURLMaxExpiry(30 * time.Minute))
+	defer teardown()
+
+	var requests int
+	var params StreamSignedURLParameters
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		requests++
+		params = StreamSignedURLParameters{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"token": "token"}}`)
+	})
+
+	within := int(time.Now().Add(20 * time.Minute).Unix())
+	_, err := client.StreamCreateSignedURL(context.Background(), StreamSignedURLParameters{AccountID: testAccountID, VideoID: testVideoID, EXP: within})
+	require.NoError(t, err)
+	assert.Equal(t, within, params.EXP)
+
+	// Without an expiry the API default of one hour would exceed the cap.
+	before := time.Now().Add(30 * time.Minute).Unix()
+	_, err = client.StreamCreateSignedURL(context.Background(), StreamSignedURLParameters{AccountID: testAccountID, VideoID: testVideoID})
+	require.NoError(t, err)
+	after := time.Now().Add(30 * time.Minute).Unix()
+	assert.GreaterOrEqual(t, int64(params.EXP), before)
+	assert.LessOrEqual(t, int64(params.EXP), after)
+	assert.Equal(t, 2, requests)
+
+	over := int(time.Now().Add(2 * time.Hour).Unix())
+	_, err = client.StreamCreateSignedURL(context.Background(), StreamSignedURLParameters{AccountID: testAccountID, VideoID: testVideoID, EXP: over})
+	assert.ErrorIs(t, err, ErrStreamSignedURLExpiryTooLong)
+	assert.Equal(t, 2, requests, "a token over the cap should not be requested")
+
+	tokens, err := client.GenerateStreamSignedTokens(context.Background(), AccountIdentifier(testAccountID), []string{testVideoID}, GenerateStreamSignedTokensParameters{EXP: over})
+	assert.ErrorIs(t, err, ErrStreamSignedURLExpiryTooLong)
+	assert.Empty(t, tokens)
+	assert.Equal(t, 2, requests)
+}
+
+func TestStream_CreateSignedURL_DefaultExpiryOverMax(t *testing.T) {
+	setup(UsingStreamSignedURLExpiry(2*time.Hour), UsingStreamSignedURLMaxExpiry(30*time.Minute))
+	defer teardown()
+
+	var params StreamSignedURLParameters
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/token", func(w http.ResponseWriter, r *http.Request) {
+		params = StreamSignedURLParameters{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"token": "token"}}`)
+	})
+
+	// The configured default is longer than the cap, so it is shortened
+	// rather than rejected.
+	before := time.Now().Add(30 * time.Minute).Unix()
+	_, err := client.StreamCreateSignedURL(context.Background(), StreamSignedURLParameters{AccountID: testAccountID, VideoID: testVideoID})
+	require.NoError(t, err)
+	after := time.Now().Add(30 * time.Minute).Unix()
+	assert.GreaterOrEqual(t, int64(params.EXP), before)
+	assert.LessOrEqual(t, int64(params.EXP), after)
+
+	tokens, err := client.GenerateStreamSignedTokens(context.Background(), AccountIdentifier(testAccountID), []string{testVideoID}, GenerateStreamSignedTokensParameters{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{testVideoID: "token"}, tokens)
+	assert.LessOrEqual(t, int64(params.EXP), time.Now().Add(30*time.Minute).Unix())
+
+	over := int(time.Now().Add(time.Hour).Unix())
+	_, err = client.StreamCreateSignedURL(context.Background(), StreamSignedURLParameters{AccountID: testAccountID, VideoID: testVideoID, EXP: over})
+	assert.ErrorIs(t, err, ErrStreamSignedURLExpiryTooLong, "an explicit expiry over the cap is still rejected")
+}
+
+func TestStream_CreateSignedURL_InvalidAccessRules(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID+"/token", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent for invalid access rules")
+	})
+
+	rules := map[string]StreamAccessRule{
+		"unknown type":      {Type: "ip.asn", Action: "allow"},
+		"unknown action":    {Type: "any", Action: "deny"},
+		"missing action":    {Type: "any"},
+		"empty country":     {Type: "ip.geoip.country", Action: "allow"},
+		"blank country":     {Type: "ip.geoip.country", Country: []string{""}, Action: "allow"},
+		"empty ip":          {Type: "ip.src", Action: "block"},
+		"blank ip":          {Type: "ip.src", IP: []string{""}, Action: "block"},
+		"any with a filter": {Type: "any", Country: []string{"US"}, Action: "block"},
+	}
+	for name, rule := range rules {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.StreamCreateSignedURL(context.Background(), StreamSignedURLParameters{
+				AccountID:   testAccountID,
+				VideoID:     testVideoID,
+				AccessRules: []StreamAccessRule{{Type: "any", Action: "allow"}, rule},
+			})
+			assert.ErrorIs(t, err, ErrInvalidStreamAccessRule)
+		})
+	}
 }
 
 func TestStream_TUSUploadMetadataToTUSCsv(t *testing.T) {
@@ -586,6 +1108,21 @@ func TestStream_TUSUploadMetadataToTUSCsv(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "name dGVzdC5tcDQ=,requiresignedurls,allowedorigins ZXhhbXBsZS5jb20=,thumbnailtimestamppct MC41,scheduledDeletion MjAyMy0xMC0wMVQwMjoyMDowMFo=,watermark d2F0ZXJtYXJrLXByb2ZpbGUtdWlk", csv)
 
+	md = TUSUploadMetadata{Name: "test.mp4", Meta: map[string]string{"tenant": "acme", "archived": ""}}
+	csv, err = md.ToTUSCsv()
+	assert.NoError(t, err)
+	assert.Equal(t, "name dGVzdC5tcDQ=,archived,tenant YWNtZQ==", csv)
+
+	for _, key := range []string{"", "has space", "has,comma", "ünicode"} {
+		md = TUSUploadMetadata{Meta: map[string]string{key: "value"}}
+		_, err = md.ToTUSCsv()
+		assert.Error(t, err, "key %q should be rejected", key)
+	}
+
+	md = TUSUploadMetadata{Name: "test.mp4", Meta: map[string]string{"name": "other.mp4"}}
+	_, err = md.ToTUSCsv()
+	assert.Error(t, err, "duplicate keys should be rejected")
+
 	// empty metadata should return empty string
 	md = TUSUploadMetadata{}
 	csv, err = md.ToTUSCsv()
@@ -646,3 +1183,295 @@ func TestStream_StreamInitiateTUSVideoUpload(t *testing.T) {
 		assert.Equal(t, "1.0.0", out.ResponseHeaders.Get("Tus-Resumable"))
 	}
 }
+
+func TestStream_CheckStreamAllowedOrigins(t *testing.T) {
+	tests := map[string]struct {
+		origins           []string
+		requireSignedURLs bool
+		wantErr           bool
+	}{
+		"no origins, no signed URLs":   {},
+		"no origins, signed URLs":      {requireSignedURLs: true},
+		"origins, signed URLs":         {origins: []string{"example.com"}, requireSignedURLs: true},
+		"origins, no signed URLs":      {origins: []string{"example.com"}, wantErr: true},
+		"empty origins, no signed URL": {origins: []string{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, strict := range []bool{true, false} {
+				logger := &recordingLogger{}
+				api, err := New("deadbeef", "cloudflare@example.org", UsingLogger(logger), UsingStrictStreamAccessSettings(strict))
+				require.NoError(t, err)
+
+				err = api.checkStreamAllowedOrigins("StreamUploadFromURL", tc.origins, tc.requireSignedURLs)
+				switch {
+				case !tc.wantErr:
+					assert.NoError(t, err)
+					assert.Empty(t, logger.lines)
+				case strict:
+					assert.ErrorIs(t, err, ErrAllowedOriginsWithoutSignedURLs)
+					assert.Empty(t, logger.lines)
+				default:
+					assert.NoError(t, err)
+					assert.Len(t, logger.lines, 1, "expected a warning to be logged")
+				}
+			}
+		})
+	}
+}
+
+func TestStream_StrictStreamAccessSettings(t *testing.T) {
+	setup(UsingStrictStreamAccessSettings(true))
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/direct_upload", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "`+testVideoID+`"}}`)
+	})
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testVideoID, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "`+testVideoID+`"}}`)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/live_inputs", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "`+testLiveInputID+`"}}`)
+	})
+
+	params := StreamCreateVideoParameters{AccountID: testAccountID, MaxDurationSeconds: 60, AllowedOrigins: []string{"example.com"}}
+	video, err := client.StreamCreateVideoDirectURL(context.Background(), params)
+	assert.ErrorIs(t, err, ErrAllowedOriginsWithoutSignedURLs)
+	assert.Equal(t, testVideoID, video.UID, "the result should be returned with the warning")
+	assert.Equal(t, 1, requests, "the request should still be sent")
+
+	params.RequireSignedURLs = true
+	_, err = client.StreamCreateVideoDirectURL(context.Background(), params)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	updated, err := client.UpdateStreamVideo(context.Background(), AccountIdentifier(testAccountID), UpdateStreamVideoParameters{
+		VideoID:           testVideoID,
+		AllowedOrigins:    []string{"example.com"},
+		RequireSignedURLs: BoolPtr(false),
+	})
+	assert.ErrorIs(t, err, ErrAllowedOriginsWithoutSignedURLs)
+	assert.Equal(t, testVideoID, updated.UID)
+
+	input, err := client.CreateStreamLiveInput(context.Background(), AccountIdentifier(testAccountID), CreateStreamLiveInputParameters{
+		Recording: &StreamLiveInputRecording{AllowedOrigins: []string{"example.com"}},
+	})
+	assert.ErrorIs(t, err, ErrAllowedOriginsWithoutSignedURLs)
+	assert.Equal(t, testLiveInputID, input.UID)
+	assert.Equal(t, 4, requests)
+}
+
+func TestStream_StrictStreamAccessSettings_RequestError(t *testing.T) {
+	setup(UsingStrictStreamAccessSettings(true))
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/direct_upload", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10005, "message": "bad request"}], "messages": [], "result": null}`)
+	})
+
+	params := StreamCreateVideoParameters{AccountID: testAccountID, MaxDurationSeconds: 60, AllowedOrigins: []string{"example.com"}}
+	_, err := client.StreamCreateVideoDirectURL(context.Background(), params)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrAllowedOriginsWithoutSignedURLs, "a failed request should return its own error")
+}
+
+func TestStreamVideoStatus(t *testing.T) {
+	tests := map[string]struct {
+		json        string
+		want        StreamVideoStatus
+		ready       bool
+		errored     bool
+		errorReason string
+	}{
+		"pendingupload": {
+			json: `{"state": "pendingupload"}`,
+			want: StreamVideoStatus{State: "pendingupload"},
+		},
+		"inprogress": {
+			json: `{"state": "inprogress", "pctComplete": "42.500000", "errorReasonCode": "", "errorReasonText": ""}`,
+			want: StreamVideoStatus{State: "inprogress", PctComplete: "42.500000"},
+		},
+		"live-inprogress": {
+			json: `{"state": "live-inprogress"}`,
+			want: StreamVideoStatus{State: StreamVideoStateLiveInProgress},
+		},
+		"ready": {
+			json:  `{"state": "ready", "pctComplete": "100.000000"}`,
+			want:  StreamVideoStatus{State: "ready", PctComplete: "100.000000"},
+			ready: true,
+		},
+		"error": {
+			json:        `{"state": "error", "pctComplete": "0", "errorReasonCode": "ERR_DURATION_EXCEED_CONSTRAINT", "errorReasonText": "The video duration exceeds the maximum duration allowed."}`,
+			want:        StreamVideoStatus{State: "error", PctComplete: "0", ErrorReasonCode: "ERR_DURATION_EXCEED_CONSTRAINT", ErrorReasonText: "The video duration exceeds the maximum duration allowed."},
+			errored:     true,
+			errorReason: "ERR_DURATION_EXCEED_CONSTRAINT: The video duration exceeds the maximum duration allowed.",
+		},
+		"error without text": {
+			json:        `{"state": "error", "errorReasonCode": "ERR_NON_VIDEO"}`,
+			want:        StreamVideoStatus{State: "error", ErrorReasonCode: "ERR_NON_VIDEO"},
+			errored:     true,
+			errorReason: "ERR_NON_VIDEO",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var video StreamVideo
+			require.NoError(t, json.Unmarshal([]byte(`{"uid": "`+testVideoID+`", "status": `+tc.json+`}`), &video))
+			assert.Equal(t, tc.want, video.Status)
+			assert.Equal(t, tc.ready, video.Status.IsReady())
+			assert.Equal(t, tc.ready, video.IsReady())
+			assert.Equal(t, tc.errored, video.Status.IsErrored())
+			assert.Equal(t, tc.errorReason, video.Status.ErrorReason())
+		})
+	}
+}
+
+func TestStream_ListVideos_Creator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		assert.Equal(t, "creator-id_abcde12345", r.URL.Query().Get("creator"))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": [
+    {"uid": "%s", "creator": "creator-id_abcde12345"}
+  ]
+}`, testVideoID)
+	})
+
+	out, err := client.StreamListVideos(context.Background(), StreamListParameters{AccountID: testAccountID, Creator: "creator-id_abcde12345"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []StreamVideo{{UID: testVideoID, Creator: "creator-id_abcde12345"}}, out)
+	}
+}
+
+func TestStream_ListVideos_CreatedRange(t *testing.T) {
+	setup()
+	defer teardown()
+
+	after := time.Date(2014, time.January, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2014, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		assert.Equal(t, "2014-01-01T00:00:00Z", r.URL.Query().Get("after"))
+		assert.Equal(t, "2014-02-01T00:00:00Z", r.URL.Query().Get("before"))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": [{"uid": "%s"}]}`, testVideoID)
+	})
+
+	out, err := client.StreamListVideos(context.Background(), StreamListParameters{AccountID: testAccountID, After: &after, Before: &before})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []StreamVideo{{UID: testVideoID}}, out)
+	}
+
+	_, err = client.StreamListVideos(context.Background(), StreamListParameters{AccountID: testAccountID, After: &before, Before: &after})
+	assert.ErrorIs(t, err, ErrInvalidStreamListRange)
+
+	_, err = client.StreamListVideos(context.Background(), StreamListParameters{AccountID: testAccountID, After: &after, Before: &after})
+	assert.ErrorIs(t, err, ErrInvalidStreamListRange)
+}
+
+func TestStream_StreamBasePath(t *testing.T) {
+	assert.Equal(t, "/accounts/"+testAccountID+"/stream", streamBasePath(testAccountID))
+	assert.Equal(t, "/accounts/"+testAccountID+"/stream/live_inputs", streamBasePath(testAccountID)+"/live_inputs")
+	assert.Equal(t, "/accounts/a%2Fb%3Fc/stream", streamBasePath("a/b?c"))
+}
+
+func TestStream_NormalizeStreamAllowedOrigins(t *testing.T) {
+	origins, err := normalizeStreamAllowedOrigins(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, origins)
+
+	origins, err = normalizeStreamAllowedOrigins([]string{" Example.com ", "https://example.com/", "http://*.example.net:8080", "*"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "*.example.net", "*"}, origins)
+
+	for _, origin := range []string{"", "example.com/videos", "https://example.com/videos", "foo.*.example.com", "user@example.com"} {
+		_, err := normalizeStreamAllowedOrigins([]string{origin})
+		assert.ErrorIs(t, err, ErrInvalidStreamAllowedOrigin, origin)
+	}
+}
+
+func TestStreamVideoInput_Resolution(t *testing.T) {
+	var video StreamVideo
+	require.NoError(t, json.Unmarshal([]byte(`{"uid": "`+testVideoID+`", "duration": 5.5, "input": {"width": 1920, "height": 1080}}`), &video))
+	assert.Equal(t, StreamVideoInput{Width: 1920, Height: 1080}, video.Input)
+	assert.Equal(t, 5.5, video.Duration)
+	assert.Equal(t, "1920x1080", video.Input.Resolution())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"uid": "`+testVideoID+`", "duration": -1, "input": {"width": -1, "height": -1}}`), &video))
+	assert.Empty(t, video.Input.Resolution())
+	assert.Empty(t, StreamVideoInput{}.Resolution())
+}
+
+func TestStreamVideo_UnmarshalJSONTimestamps(t *testing.T) {
+	want, _ := time.Parse(time.RFC3339, "2014-01-02T02:20:00Z")
+	wantNano, _ := time.Parse(time.RFC3339Nano, "2014-01-02T02:20:00.123456Z")
+
+	for value, expected := range map[string]*time.Time{
+		"2014-01-02T02:20:00Z":        &want,
+		"2014-01-02T02:20:00.123456Z": &wantNano,
+		"2014-01-02T02:20:00+00:00":   &want,
+		"2014-01-02T02:20:00":         &want,
+		"2014-01-02 02:20:00":         &want,
+		"2014-01-02 02:20:00Z":        &want,
+		"02/01/2014 02:20":            nil,
+	} {
+		var video StreamVideo
+		err := json.Unmarshal([]byte(`{"uid": "`+testVideoID+`", "created": "`+value+`", "uploaded": "`+value+`"}`), &video)
+		require.NoError(t, err, value)
+		assert.Equal(t, testVideoID, video.UID, value)
+
+		if expected == nil {
+			assert.Nil(t, video.Created, value)
+			assert.Nil(t, video.Uploaded, value)
+			continue
+		}
+		if assert.NotNil(t, video.Created, value) && assert.NotNil(t, video.Uploaded, value) {
+			assert.True(t, expected.Equal(*video.Created), value)
+			assert.True(t, expected.Equal(*video.Uploaded), value)
+		}
+	}
+}
+
+func TestStreamVideo_PlaybackURLs(t *testing.T) {
+	playback := StreamVideoPlayback{
+		HLS:  "https://customer-f33zs165nr7gyfy4.cloudflarestream.com/" + testVideoID + "/manifest/video.m3u8",
+		Dash: "https://customer-f33zs165nr7gyfy4.cloudflarestream.com/" + testVideoID + "/manifest/video.mpd",
+	}
+
+	ready := StreamVideo{UID: testVideoID, ReadyToStream: true, Status: StreamVideoStatus{State: "ready"}, Playback: playback}
+	assert.True(t, ready.IsReady())
+	out, err := ready.PlaybackURLs(false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, playback, out)
+	}
+
+	processing := StreamVideo{UID: testVideoID, Status: StreamVideoStatus{State: "inprogress", PctComplete: "40.0"}, Playback: playback}
+	assert.False(t, processing.IsReady())
+	_, err = processing.PlaybackURLs(false)
+	assert.Equal(t, ErrStreamVideoNotReady, err)
+
+	out, err = processing.PlaybackURLs(true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, playback, out)
+	}
+}
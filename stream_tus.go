@@ -0,0 +1,310 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultTUSChunkSize is the number of bytes sent in each PATCH request
+	// when no chunk size is provided. Cloudflare recommends 50MiB chunks.
+	DefaultTUSChunkSize int64 = 50 * 1024 * 1024
+
+	// tusChunkSizeMultiple is the value every chunk, other than the final one,
+	// must be a multiple of.
+	tusChunkSizeMultiple int64 = 256 * 1024
+)
+
+var (
+	// ErrInvalidTUSChunkSize is for when the chunk size is not a positive multiple of 256KiB.
+	ErrInvalidTUSChunkSize = errors.New("tus chunk size must be a positive multiple of 256KiB")
+	// ErrInvalidTUSUploadLength is for when the upload length is negative.
+	ErrInvalidTUSUploadLength = errors.New("tus upload length must not be negative")
+	// ErrTUSUploadTooLarge is for when the upload exceeds the Tus-Max-Size advertised by the server.
+	ErrTUSUploadTooLarge = errors.New("upload length exceeds the maximum size accepted by the server")
+	// ErrMissingTUSUploadReader is for when the Reader is required but missing.
+	ErrMissingTUSUploadReader = errors.New("required upload reader missing")
+	// ErrUnexpectedTUSUploadOffset is for when the server acknowledges a different offset than was sent.
+	ErrUnexpectedTUSUploadOffset = errors.New("unexpected tus upload offset")
+	// ErrStreamUploadComplete is for when an upload cannot be canceled because the video has already been uploaded.
+	ErrStreamUploadComplete = errors.New("upload has already completed")
+)
+
+// UploadStreamVideoTUSParameters are the parameters used when uploading a
+// video using the TUS protocol.
+type UploadStreamVideoTUSParameters struct {
+	// Reader is the source of the video content.
+	Reader io.Reader
+
+	// Size is the total length of the video in bytes.
+	Size int64
+
+	// ChunkSize is the number of bytes sent in each PATCH request. It must be
+	// a multiple of 256KiB and defaults to DefaultTUSChunkSize.
+	ChunkSize int64
+
+	DirectUserUpload bool
+	UploadCreator    string
+	Metadata         TUSUploadMetadata
+
+	// Progress, if set, is called after every chunk is acknowledged by the
+	// server with the number of bytes uploaded so far and the total size.
+	Progress func(bytesSent, totalBytes int64)
+}
+
+// UploadStreamVideoTUS uploads a video using the TUS protocol. The upload is
+// created using StreamInitiateTUSVideoUpload and the content is then sent in
+// chunks of ChunkSize bytes. Uploads exceeding the Tus-Max-Size advertised by
+// the server are rejected before any content is sent.
+//
+// The UID of the created video is returned. It is also returned alongside the
+// error when the upload is too large or sending the content fails, including
+// when ctx is canceled, so that the upload can be removed with
+// CancelStreamUpload.
+//
+// API Reference: https://developers.cloudflare.com/stream/uploading-videos/upload-video-file/#resumable-uploads-with-tus-for-large-files
+func (api *API) UploadStreamVideoTUS(ctx context.Context, rc *ResourceContainer, params UploadStreamVideoTUSParameters) (string, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return "", err
+	}
+
+	if params.Reader == nil {
+		return "", ErrMissingTUSUploadReader
+	}
+
+	if params.Size == 0 {
+		return "", ErrMissingUploadLength
+	}
+	if params.Size < 0 {
+		return "", ErrInvalidTUSUploadLength
+	}
+
+	if params.ChunkSize == 0 {
+		params.ChunkSize = DefaultTUSChunkSize
+	}
+	if params.ChunkSize < 0 || params.ChunkSize%tusChunkSizeMultiple != 0 {
+		return "", ErrInvalidTUSChunkSize
+	}
+
+	upload, err := api.StreamInitiateTUSVideoUpload(ctx, rc, StreamInitiateTUSUploadParameters{
+		DirectUserUpload: params.DirectUserUpload,
+		TusResumable:     TusProtocolVersion1_0_0,
+		UploadLength:     params.Size,
+		UploadCreator:    params.UploadCreator,
+		Metadata:         params.Metadata,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	uploadURL := upload.ResponseHeaders.Get("Location")
+	if uploadURL == "" {
+		return "", ErrMissingUploadURL
+	}
+
+	videoID := tusUploadVideoID(uploadURL, upload.ResponseHeaders)
+
+	if header := upload.ResponseHeaders.Get("Tus-Max-Size"); header != "" {
+		maxSize, err := strconv.ParseInt(header, 10, 64)
+		if err != nil {
+			return videoID, fmt.Errorf("invalid Tus-Max-Size header %q: %w", header, err)
+		}
+		if params.Size > maxSize {
+			return videoID, fmt.Errorf("%w: %d bytes exceeds %d bytes", ErrTUSUploadTooLarge, params.Size, maxSize)
+		}
+	}
+	if err := api.uploadTUSChunks(ctx, uploadURL, params.Reader, 0, params.Size, params.ChunkSize, params.Progress); err != nil {
+		return videoID, wrapStreamTransportError("UploadStreamVideoTUS", uploadURL, err)
+	}
+
+	return videoID, nil
+}
+
+// CancelStreamUpload deletes the placeholder video of a direct or TUS upload
+// which has not completed. A TUS upload in progress is stopped by canceling
+// the context passed to UploadStreamVideoTUS before calling
+// CancelStreamUpload, as no further chunks are sent once it is canceled.
+//
+// ErrStreamUploadComplete is returned without deleting the video when its
+// content has already been received; use StreamDeleteVideo to delete it.
+func (api *API) CancelStreamUpload(ctx context.Context, rc *ResourceContainer, videoID string) error {
+	video, err := api.StreamGetVideo(ctx, StreamParameters{AccountID: rc.Identifier, VideoID: videoID})
+	if err != nil {
+		return err
+	}
+
+	switch video.Status.State {
+	case "", "pendingupload", "downloading":
+	default:
+		return fmt.Errorf("%w: video %s is %s", ErrStreamUploadComplete, videoID, video.Status.State)
+	}
+
+	return api.StreamDeleteVideo(ctx, StreamParameters{AccountID: rc.Identifier, VideoID: videoID})
+}
+
+// ResumeStreamVideoTUS resumes an interrupted TUS upload using the upload URL
+// returned when it was created. The current offset is discovered from the
+// server and r is advanced to it before the remaining content is sent. r must
+// provide the complete video content from the beginning; readers implementing
+// io.Seeker are seeked rather than read up to the offset.
+//
+// The UID of the uploaded video is returned.
+//
+// API Reference: https://tus.io/protocols/resumable-upload#head
+func (api *API) ResumeStreamVideoTUS(ctx context.Context, uploadURL string, r io.Reader) (string, error) {
+	if uploadURL == "" {
+		return "", ErrMissingUploadURL
+	}
+
+	if r == nil {
+		return "", ErrMissingTUSUploadReader
+	}
+
+	resp, err := api.tusRequest(ctx, http.MethodHead, uploadURL, nil, nil)
+	if err != nil {
+		return "", wrapStreamTransportError("ResumeStreamVideoTUS", uploadURL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return "", ErrInvalidStatusCode
+	}
+
+	offset, err := strconv.ParseInt(resp.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid Upload-Offset header %q: %w", resp.Header.Get("Upload-Offset"), err)
+	}
+
+	size, err := strconv.ParseInt(resp.Header.Get("Upload-Length"), 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid Upload-Length header %q: %w", resp.Header.Get("Upload-Length"), err)
+	}
+
+	if seeker, ok := r.(io.Seeker); ok {
+		if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+			return "", fmt.Errorf("failed to seek upload content to offset %d: %w", offset, err)
+		}
+	} else if _, err := io.CopyN(io.Discard, r, offset); err != nil {
+		return "", fmt.Errorf("failed to read upload content up to offset %d: %w", offset, err)
+	}
+
+	if err := api.uploadTUSChunks(ctx, uploadURL, r, offset, size, DefaultTUSChunkSize, nil); err != nil {
+		return "", wrapStreamTransportError("ResumeStreamVideoTUS", uploadURL, err)
+	}
+
+	return tusUploadVideoID(uploadURL, resp.Header), nil
+}
+
+// uploadTUSChunks sends the remaining content of r to uploadURL, starting at
+// offset, in PATCH requests of chunkSize bytes. progress, if not nil, is
+// called after every acknowledged chunk.
+func (api *API) uploadTUSChunks(ctx context.Context, uploadURL string, r io.Reader, offset, size, chunkSize int64, progress func(bytesSent, totalBytes int64)) error {
+	buf := make([]byte, chunkSize)
+	for offset < size {
+		remaining := size - offset
+		if remaining < chunkSize {
+			buf = buf[:remaining]
+		}
+
+		n, err := io.ReadFull(r, buf)
+		if err != nil {
+			return fmt.Errorf("failed to read upload content at offset %d: %w", offset, err)
+		}
+
+		headers := http.Header{}
+		headers.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+		headers.Set("Content-Type", "application/offset+octet-stream")
+
+		resp, err := api.tusRequest(ctx, http.MethodPatch, uploadURL, buf[:n], headers)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusNoContent {
+			return ErrInvalidStatusCode
+		}
+
+		next, err := strconv.ParseInt(resp.Header.Get("Upload-Offset"), 10, 64)
+		if err != nil || next != offset+int64(n) {
+			return fmt.Errorf("%w: sent %d bytes from offset %d, server acknowledged %q", ErrUnexpectedTUSUploadOffset, n, offset, resp.Header.Get("Upload-Offset"))
+		}
+		offset = next
+
+		if progress != nil {
+			progress(offset, size)
+		}
+	}
+
+	return nil
+}
+
+// tusRequest makes a request against a TUS upload URL. Upload URLs are
+// absolute and may point outside of the API so authentication is only
+// included when the URL is relative to BaseURL.
+func (api *API) tusRequest(ctx context.Context, method, uploadURL string, body []byte, headers http.Header) (*http.Response, error) {
+	if err := api.waitRateLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, uploadURL, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request creation failed: %w", err)
+	}
+
+	copyHeader(req.Header, headers)
+	req.Header.Set("Tus-Resumable", string(TusProtocolVersion1_0_0))
+
+	if strings.HasPrefix(uploadURL, api.BaseURL) {
+		if api.authType&AuthKeyEmail != 0 {
+			req.Header.Set("X-Auth-Key", api.APIKey)
+			req.Header.Set("X-Auth-Email", api.APIEmail)
+		}
+		if api.authType&AuthUserService != 0 {
+			req.Header.Set("X-Auth-User-Service-Key", api.APIUserServiceKey)
+		}
+		if api.authType&AuthToken != 0 {
+			req.Header.Set("Authorization", "Bearer "+api.APIToken)
+		}
+	}
+
+	if api.UserAgent != "" {
+		req.Header.Set("User-Agent", api.UserAgent)
+	}
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The body of TUS responses carries no information, drain it so the
+	// connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp, nil
+}
+
+// tusUploadVideoID returns the video UID for a TUS upload, preferring the
+// stream-media-id header and falling back to the last path segment of the
+// upload URL.
+func tusUploadVideoID(uploadURL string, headers http.Header) string {
+	if id := headers.Get("stream-media-id"); id != "" {
+		return id
+	}
+
+	path := uploadURL
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	return path[strings.LastIndex(path, "/")+1:]
+}
@@ -0,0 +1,277 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTUSVideoID = "278f2a7e763c73dedc064b965d2cfbed"
+
+// mockTUSServer registers handlers for creating a TUS upload and receiving
+// its chunks. The Upload-Metadata, received content and the size of every
+// PATCH are recorded.
+type mockTUSServer struct {
+	metadata string
+	length   int64
+	maxSize  int64
+	received bytes.Buffer
+	chunks   []int
+}
+
+func (m *mockTUSServer) register(t *testing.T) {
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		m.metadata = r.Header.Get("Upload-Metadata")
+		w.Header().Set("Location", server.URL+"/tus/"+testTUSVideoID+"?tusv2=true")
+		w.Header().Set("stream-media-id", testTUSVideoID)
+		w.Header().Set("Tus-Resumable", "1.0.0")
+		if m.maxSize > 0 {
+			w.Header().Set("Tus-Max-Size", strconv.FormatInt(m.maxSize, 10))
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	mux.HandleFunc("/tus/"+testTUSVideoID, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodHead:
+			w.Header().Set("Upload-Offset", strconv.Itoa(m.received.Len()))
+			w.Header().Set("Upload-Length", strconv.FormatInt(m.length, 10))
+			w.WriteHeader(http.StatusOK)
+		case http.MethodPatch:
+			assert.Equal(t, "1.0.0", r.Header.Get("Tus-Resumable"))
+			assert.Equal(t, "application/offset+octet-stream", r.Header.Get("Content-Type"))
+			assert.Equal(t, strconv.Itoa(m.received.Len()), r.Header.Get("Upload-Offset"))
+
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			m.received.Write(b)
+			m.chunks = append(m.chunks, len(b))
+
+			w.Header().Set("Upload-Offset", strconv.Itoa(m.received.Len()))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+}
+
+func TestStream_UploadStreamVideoTUS(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tus := &mockTUSServer{}
+	tus.register(t)
+
+	content := bytes.Repeat([]byte("a"), 600*1024)
+	uid, err := client.UploadStreamVideoTUS(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoTUSParameters{
+		Reader:    bytes.NewReader(content),
+		Size:      int64(len(content)),
+		ChunkSize: 256 * 1024,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, testTUSVideoID, uid)
+		assert.Equal(t, []int{256 * 1024, 256 * 1024, 88 * 1024}, tus.chunks)
+		assert.Equal(t, content, tus.received.Bytes())
+	}
+}
+
+func TestStream_UploadStreamVideoTUS_Progress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tus := &mockTUSServer{}
+	tus.register(t)
+
+	var sent, totals []int64
+	content := bytes.Repeat([]byte("a"), 600*1024)
+	_, err := client.UploadStreamVideoTUS(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoTUSParameters{
+		Reader:    bytes.NewReader(content),
+		Size:      int64(len(content)),
+		ChunkSize: 256 * 1024,
+		Progress: func(bytesSent, totalBytes int64) {
+			sent = append(sent, bytesSent)
+			totals = append(totals, totalBytes)
+		},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int64{256 * 1024, 512 * 1024, 600 * 1024}, sent)
+		assert.Equal(t, []int64{600 * 1024, 600 * 1024, 600 * 1024}, totals)
+	}
+}
+
+func TestStream_UploadStreamVideoTUS_Metadata(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tus := &mockTUSServer{}
+	tus.register(t)
+
+	content := []byte("video")
+	_, err := client.UploadStreamVideoTUS(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoTUSParameters{
+		Reader: bytes.NewReader(content),
+		Size:   int64(len(content)),
+		Metadata: TUSUploadMetadata{
+			Name:              "test.mp4",
+			RequireSignedURLs: true,
+			Meta:              map[string]string{"tenant": "acme"},
+		},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "name dGVzdC5tcDQ=,requiresignedurls,tenant YWNtZQ==", tus.metadata)
+	}
+
+	_, err = client.UploadStreamVideoTUS(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoTUSParameters{
+		Reader:   bytes.NewReader(content),
+		Size:     int64(len(content)),
+		Metadata: TUSUploadMetadata{Meta: map[string]string{"bad key": "value"}},
+	})
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrMarshallingTUSMetadata))
+	}
+}
+
+func TestStream_UploadStreamVideoTUS_Validation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.UploadStreamVideoTUS(context.Background(), AccountIdentifier(""), UploadStreamVideoTUSParameters{})
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	_, err = client.UploadStreamVideoTUS(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoTUSParameters{})
+	assert.Equal(t, ErrMissingTUSUploadReader, err)
+
+	_, err = client.UploadStreamVideoTUS(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoTUSParameters{
+		Reader: bytes.NewReader(nil),
+	})
+	assert.Equal(t, ErrMissingUploadLength, err)
+
+	_, err = client.UploadStreamVideoTUS(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoTUSParameters{
+		Reader: bytes.NewReader(nil),
+		Size:   -1,
+	})
+	assert.Equal(t, ErrInvalidTUSUploadLength, err)
+
+	_, err = client.UploadStreamVideoTUS(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoTUSParameters{
+		Reader:    bytes.NewReader([]byte("a")),
+		Size:      1,
+		ChunkSize: 1000,
+	})
+	assert.Equal(t, ErrInvalidTUSChunkSize, err)
+}
+
+func TestStream_UploadStreamVideoTUS_ExceedsMaxSize(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tus := &mockTUSServer{maxSize: 1024}
+	tus.register(t)
+
+	content := bytes.Repeat([]byte("a"), 2048)
+	uid, err := client.UploadStreamVideoTUS(context.Background(), AccountIdentifier(testAccountID), UploadStreamVideoTUSParameters{
+		Reader: bytes.NewReader(content),
+		Size:   int64(len(content)),
+	})
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrTUSUploadTooLarge))
+		assert.Equal(t, testTUSVideoID, uid, "the created upload should be returned so that it can be cancelled")
+		assert.Empty(t, tus.chunks, "no content should be sent for an oversized upload")
+	}
+}
+
+func TestStream_CancelStreamUpload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tus := &mockTUSServer{}
+	tus.register(t)
+
+	state := "pendingupload"
+	deleted := false
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/"+testTUSVideoID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": "%s", "status": {"state": "%s"}}}`, testTUSVideoID, state)
+		case http.MethodDelete:
+			deleted = true
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": ""}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	content := bytes.Repeat([]byte("a"), 600*1024)
+	uid, err := client.UploadStreamVideoTUS(ctx, AccountIdentifier(testAccountID), UploadStreamVideoTUSParameters{
+		Reader:    bytes.NewReader(content),
+		Size:      int64(len(content)),
+		ChunkSize: 256 * 1024,
+		Progress: func(bytesSent, totalBytes int64) {
+			cancel()
+		},
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, testTUSVideoID, uid)
+	assert.Equal(t, []int{256 * 1024}, tus.chunks, "no chunks should be sent once the upload is canceled")
+
+	err = client.CancelStreamUpload(context.Background(), AccountIdentifier(""), uid)
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	err = client.CancelStreamUpload(context.Background(), AccountIdentifier(testAccountID), uid)
+	assert.NoError(t, err)
+	assert.True(t, deleted, "expected the pending video to be deleted")
+
+	state = "queued"
+	deleted = false
+	err = client.CancelStreamUpload(context.Background(), AccountIdentifier(testAccountID), uid)
+	assert.ErrorIs(t, err, ErrStreamUploadComplete)
+	assert.False(t, deleted, "a completed upload should not be deleted")
+}
+
+func TestStream_ResumeStreamVideoTUS(t *testing.T) {
+	setup()
+	defer teardown()
+
+	content := bytes.Repeat([]byte("abcd"), 200*1024)
+	uploadURL := server.URL + "/tus/" + testTUSVideoID + "?tusv2=true"
+
+	tus := &mockTUSServer{length: int64(len(content))}
+	tus.register(t)
+
+	_, err := client.ResumeStreamVideoTUS(context.Background(), "", bytes.NewReader(content))
+	assert.Equal(t, ErrMissingUploadURL, err)
+
+	_, err = client.ResumeStreamVideoTUS(context.Background(), uploadURL, nil)
+	assert.Equal(t, ErrMissingTUSUploadReader, err)
+
+	// seekable readers are moved to the offset
+	tus.received.Write(content[:256*1024])
+	uid, err := client.ResumeStreamVideoTUS(context.Background(), uploadURL, bytes.NewReader(content))
+	if assert.NoError(t, err) {
+		assert.Equal(t, testTUSVideoID, uid)
+		assert.Equal(t, []int{len(content) - 256*1024}, tus.chunks)
+		assert.Equal(t, content, tus.received.Bytes())
+	}
+
+	// other readers are consumed up to the offset
+	tus.received.Reset()
+	tus.chunks = nil
+	tus.received.Write(content[:100])
+	uid, err = client.ResumeStreamVideoTUS(context.Background(), uploadURL, io.MultiReader(bytes.NewReader(content)))
+	if assert.NoError(t, err) {
+		assert.Equal(t, testTUSVideoID, uid)
+		assert.Equal(t, []int{len(content) - 100}, tus.chunks)
+		assert.Equal(t, content, tus.received.Bytes())
+	}
+}
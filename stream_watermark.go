@@ -0,0 +1,68 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// ErrInvalidWatermarkURL is for when the URL of a watermark image is not an absolute HTTP(S) URL.
+	ErrInvalidWatermarkURL = errors.New("watermark url must be an absolute http or https url")
+)
+
+// CreateStreamWatermarkFromURLParameters are the parameters used when
+// creating a watermark profile from an image hosted at a URL.
+type CreateStreamWatermarkFromURLParameters struct {
+	URL      string  `json:"url"`
+	Name     string  `json:"name,omitempty"`
+	Opacity  float64 `json:"opacity,omitempty"`
+	Padding  float64 `json:"padding,omitempty"`
+	Scale    float64 `json:"scale,omitempty"`
+	Position string  `json:"position,omitempty"`
+}
+
+// StreamWatermarkResponse represents an API response of a watermark profile.
+type StreamWatermarkResponse struct {
+	Response
+	Result StreamVideoWatermark `json:"result,omitempty"`
+}
+
+// CreateStreamWatermarkFromURL creates a watermark profile from an image
+// downloaded from a URL.
+//
+// Watermarks are burned in when a video is processed so a profile can only be
+// applied at upload time, using the Watermark of StreamUploadFromURLParameters,
+// StreamCreateVideoParameters or TUSUploadMetadata. There is no API to add a
+// watermark to a video which has already been uploaded; the video must be
+// uploaded again, for example by copying its MP4 download using
+// StreamUploadFromURL with the watermark set.
+//
+// API Reference: https://developers.cloudflare.com/api/operations/stream-watermark-profile-create-watermark-profiles-via-basic-upload
+func (api *API) CreateStreamWatermarkFromURL(ctx context.Context, rc *ResourceContainer, params CreateStreamWatermarkFromURLParameters) (StreamVideoWatermark, error) {
+	if err := validateAccountID(rc.Identifier); err != nil {
+		return StreamVideoWatermark{}, err
+	}
+
+	u, err := url.Parse(params.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return StreamVideoWatermark{}, ErrInvalidWatermarkURL
+	}
+
+	uri := streamBasePath(rc.Identifier) + "/watermarks"
+	res, err := api.makeRequestContext(ctx, http.MethodPost, uri, params)
+	api.auditStream(ctx, StreamAuditEvent{Operation: "CreateStreamWatermarkFromURL", AccountID: rc.Identifier}, err)
+	if err != nil {
+		return StreamVideoWatermark{}, wrapStreamTransportError("CreateStreamWatermarkFromURL", uri, err)
+	}
+
+	var r StreamWatermarkResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return StreamVideoWatermark{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	return r.Result, nil
+}
@@ -0,0 +1,79 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWatermarkID = "ea95132c15732412d22c1476fa83f27a"
+
+func TestStream_CreateStreamWatermarkFromURL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream/watermarks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"url":"https://example.com/logo.png","name":"Marketing Videos","opacity":0.75,"position":"center"}`, string(b))
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": {
+    "uid": "%s",
+    "size": 29472,
+    "height": 600,
+    "width": 400,
+    "created": "2014-01-02T02:20:00Z",
+    "downloadedFrom": "https://example.com/logo.png",
+    "name": "Marketing Videos",
+    "opacity": 0.75,
+    "padding": 0.1,
+    "scale": 0.1,
+    "position": "center"
+  }
+}`, testWatermarkID)
+	})
+
+	_, err := client.CreateStreamWatermarkFromURL(context.Background(), AccountIdentifier(""), CreateStreamWatermarkFromURLParameters{})
+	assert.Equal(t, ErrMissingAccountID, err)
+
+	for _, u := range []string{"", "logo.png", "ftp://example.com/logo.png", "https://"} {
+		_, err = client.CreateStreamWatermarkFromURL(context.Background(), AccountIdentifier(testAccountID), CreateStreamWatermarkFromURLParameters{URL: u})
+		assert.Equal(t, ErrInvalidWatermarkURL, err, "url %q", u)
+	}
+
+	out, err := client.CreateStreamWatermarkFromURL(context.Background(), AccountIdentifier(testAccountID), CreateStreamWatermarkFromURLParameters{
+		URL:      "https://example.com/logo.png",
+		Name:     "Marketing Videos",
+		Opacity:  0.75,
+		Position: "center",
+	})
+	created, _ := time.Parse(time.RFC3339, "2014-01-02T02:20:00Z")
+	if assert.NoError(t, err) {
+		assert.Equal(t, StreamVideoWatermark{
+			UID:            testWatermarkID,
+			Size:           29472,
+			Height:         600,
+			Width:          400,
+			Created:        &created,
+			DownloadedFrom: "https://example.com/logo.png",
+			Name:           "Marketing Videos",
+			Opacity:        0.75,
+			Padding:        0.1,
+			Scale:          0.1,
+			Position:       "center",
+		}, out)
+	}
+}
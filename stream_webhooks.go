@@ -0,0 +1,110 @@
+package cloudflare
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// ErrUnknownStreamWebhookEvent is for when a webhook payload is neither a
+// video event nor a live input event.
+var ErrUnknownStreamWebhookEvent = errors.New("webhook payload is not a known Stream event")
+
+// StreamWebhookEventType is the kind of a StreamWebhookEvent.
+type StreamWebhookEventType string
+
+const (
+	// StreamWebhookEventVideo is sent by the Stream webhook when a video,
+	// including the recording of a live input, is ready or fails to process.
+	StreamWebhookEventVideo StreamWebhookEventType = "video"
+	// The live input events are sent by notifications of the Stream Live
+	// Input type when the connection state of a live input changes.
+	StreamWebhookEventLiveInputConnected    StreamWebhookEventType = "live_input.connected"
+	StreamWebhookEventLiveInputDisconnected StreamWebhookEventType = "live_input.disconnected"
+	StreamWebhookEventLiveInputErrored      StreamWebhookEventType = "live_input.errored"
+)
+
+// StreamWebhookEvent is a decoded Stream webhook payload.
+type StreamWebhookEvent struct {
+	Type StreamWebhookEventType
+	// UID is the UID of the video for video events, and of the live input
+	// for live input events.
+	UID           string
+	ReadyToStream bool
+	Status        StreamVideoStatus
+	Meta          map[string]interface{}
+	// LiveInput is the UID of the live input a video was recorded from.
+	LiveInput string
+	// UpdatedAt is when the state of a live input changed.
+	UpdatedAt *time.Time
+	// Error is set for StreamWebhookEventLiveInputErrored.
+	Error *StreamWebhookEventError
+	// Video is the full video of video events.
+	Video *StreamVideo
+}
+
+// StreamWebhookEventError is the reason a live input errored.
+type StreamWebhookEventError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// IsLiveInputEvent reports whether the event is a change of the connection
+// state of a live input.
+func (e StreamWebhookEvent) IsLiveInputEvent() bool {
+	return strings.HasPrefix(string(e.Type), "live_input.")
+}
+
+// streamLiveInputWebhookPayload is the payload of a Stream Live Input
+// notification.
+type streamLiveInputWebhookPayload struct {
+	Data struct {
+		InputID          string `json:"input_id"`
+		EventType        string `json:"event_type"`
+		UpdatedAt        string `json:"updated_at"`
+		LiveInputErrored struct {
+			Error *StreamWebhookEventError `json:"error,omitempty"`
+		} `json:"live_input_errored"`
+	} `json:"data"`
+}
+
+// ParseStreamWebhookEvent decodes the body of a Stream webhook or Stream Live
+// Input notification into an event. The signature of the request should be
+// verified before the body is parsed.
+func ParseStreamWebhookEvent(body []byte) (StreamWebhookEvent, error) {
+	var live streamLiveInputWebhookPayload
+	if err := json.Unmarshal(body, &live); err != nil {
+		return StreamWebhookEvent{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	if strings.HasPrefix(live.Data.EventType, "live_input.") {
+		if live.Data.InputID == "" {
+			return StreamWebhookEvent{}, fmt.Errorf("%w: live input event without an input ID", ErrUnknownStreamWebhookEvent)
+		}
+		return StreamWebhookEvent{
+			Type:      StreamWebhookEventType(live.Data.EventType),
+			UID:       live.Data.InputID,
+			UpdatedAt: parseStreamTime(live.Data.UpdatedAt),
+			Error:     live.Data.LiveInputErrored.Error,
+		}, nil
+	}
+
+	var video StreamVideo
+	if err := json.Unmarshal(body, &video); err != nil {
+		return StreamWebhookEvent{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+	if video.UID == "" {
+		return StreamWebhookEvent{}, ErrUnknownStreamWebhookEvent
+	}
+	return StreamWebhookEvent{
+		Type:          StreamWebhookEventVideo,
+		UID:           video.UID,
+		ReadyToStream: video.ReadyToStream,
+		Status:        video.Status,
+		Meta:          video.Meta,
+		LiveInput:     video.LiveInput,
+		Video:         &video,
+	}, nil
+}
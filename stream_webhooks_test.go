@@ -0,0 +1,96 @@
+package cloudflare
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStreamWebhookEvent_RecordingReady(t *testing.T) {
+	event, err := ParseStreamWebhookEvent([]byte(`{
+  "uid": "` + testVideoID + `",
+  "creator": null,
+  "thumbnail": "https://customer-f33zs165nr7gyfy4.cloudflarestream.com/` + testVideoID + `/thumbnails/thumbnail.jpg",
+  "readyToStream": true,
+  "status": {
+    "state": "ready",
+    "pctComplete": "100.000000",
+    "errorReasonCode": "",
+    "errorReasonText": ""
+  },
+  "meta": {
+    "name": "Live Input Recording"
+  },
+  "created": "2022-06-30T17:53:12.512033Z",
+  "modified": "2022-06-30T17:53:21.774299Z",
+  "duration": 312.5,
+  "liveInput": "` + testLiveInputID + `"
+}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, StreamWebhookEventVideo, event.Type)
+	assert.False(t, event.IsLiveInputEvent())
+	assert.Equal(t, testVideoID, event.UID)
+	assert.True(t, event.ReadyToStream)
+	assert.Equal(t, "ready", event.Status.State)
+	assert.Equal(t, map[string]interface{}{"name": "Live Input Recording"}, event.Meta)
+	assert.Equal(t, testLiveInputID, event.LiveInput)
+	assert.Nil(t, event.Error)
+	if assert.NotNil(t, event.Video) {
+		assert.Equal(t, 312.5, event.Video.Duration)
+		assert.Equal(t, time.Date(2022, 6, 30, 17, 53, 12, 512033000, time.UTC), *event.Video.Created)
+	}
+}
+
+func TestParseStreamWebhookEvent_LiveInputStateChange(t *testing.T) {
+	event, err := ParseStreamWebhookEvent([]byte(`{
+  "name": "Live Webhook Test",
+  "text": "Notification type: Stream Live Input\nInput ID: ` + testLiveInputID + `\nEvent type: live_input.disconnected\nUpdated at: 2022-01-13T11:43:41.855717910Z",
+  "data": {
+    "notification_name": "Stream Live Input",
+    "input_id": "` + testLiveInputID + `",
+    "event_type": "live_input.disconnected",
+    "updated_at": "2022-01-13T11:43:41.855717910Z"
+  },
+  "ts": 1642074233
+}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, StreamWebhookEventLiveInputDisconnected, event.Type)
+	assert.True(t, event.IsLiveInputEvent())
+	assert.Equal(t, testLiveInputID, event.UID)
+	assert.Equal(t, time.Date(2022, 1, 13, 11, 43, 41, 855717910, time.UTC), *event.UpdatedAt)
+	assert.Nil(t, event.Error)
+	assert.Nil(t, event.Video)
+
+	event, err = ParseStreamWebhookEvent([]byte(`{
+  "data": {
+    "notification_name": "Stream Live Input",
+    "input_id": "` + testLiveInputID + `",
+    "event_type": "live_input.errored",
+    "updated_at": "2022-01-13T11:43:41.855717910Z",
+    "live_input_errored": {
+      "error": {
+        "code": "ERR_GOP_OUT_OF_RANGE",
+        "message": "Input GOP size or keyframe interval is out of range."
+      },
+      "video_codec": "",
+      "audio_codec": ""
+    }
+  }
+}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, StreamWebhookEventLiveInputErrored, event.Type)
+	assert.Equal(t, &StreamWebhookEventError{Code: "ERR_GOP_OUT_OF_RANGE", Message: "Input GOP size or keyframe interval is out of range."}, event.Error)
+}
+
+func TestParseStreamWebhookEvent_Unknown(t *testing.T) {
+	_, err := ParseStreamWebhookEvent([]byte(`{"name": "Other notification", "data": {"event_type": "other"}}`))
+	assert.ErrorIs(t, err, ErrUnknownStreamWebhookEvent)
+
+	_, err = ParseStreamWebhookEvent([]byte(`not json`))
+	assert.Error(t, err)
+}
@@ -181,12 +181,12 @@ func (api *API) ListTeamsListItems(ctx context.Context, rc *ResourceContainer, p
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []TeamsListItem{}, ResultInfo{}, err
+			return append([]TeamsListItem{}, teamListItems...), ResultInfo{}, err
 		}
 
 		err = json.Unmarshal(res, &lResponse)
 		if err != nil {
-			return []TeamsListItem{}, ResultInfo{}, fmt.Errorf("failed to unmarshal teams list JSON data: %w", err)
+			return append([]TeamsListItem{}, teamListItems...), ResultInfo{}, fmt.Errorf("failed to unmarshal teams list JSON data: %w", err)
 		}
 
 		teamListItems = append(teamListItems, lResponse.Result...)
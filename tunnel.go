@@ -255,12 +255,12 @@ func (api *API) ListTunnels(ctx context.Context, rc *ResourceContainer, params T
 		uri := buildURI(fmt.Sprintf("/accounts/%s/cfd_tunnel", rc.Identifier), params)
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []Tunnel{}, &ResultInfo{}, err
+			return append([]Tunnel{}, records...), &ResultInfo{}, err
 		}
 
 		err = json.Unmarshal(res, &listResponse)
 		if err != nil {
-			return []Tunnel{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]Tunnel{}, records...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 
 		records = append(records, listResponse.Result...)
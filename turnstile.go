@@ -116,11 +116,11 @@ func (api *API) ListTurnstileWidgets(ctx context.Context, rc *ResourceContainer,
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 
 		if err != nil {
-			return []TurnstileWidget{}, &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
+			return append([]TurnstileWidget{}, widgets...), &ResultInfo{}, fmt.Errorf("%s: %w", errMakeRequestError, err)
 		}
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return []TurnstileWidget{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]TurnstileWidget{}, widgets...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 
 		widgets = append(widgets, r.Result...)
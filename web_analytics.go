@@ -184,12 +184,12 @@ func (api *API) ListWebAnalyticsSites(ctx context.Context, rc *ResourceContainer
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return nil, nil, err
+			return sites, nil, err
 		}
 		var r WebAnalyticsSitesResponse
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return nil, nil, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return sites, nil, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		sites = append(sites, r.Result...)
 		lastResultInfo = r.ResultInfo
@@ -159,12 +159,12 @@ func (api *API) ListWorkersKVNamespaces(ctx context.Context, rc *ResourceContain
 
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []WorkersKVNamespace{}, &ResultInfo{}, err
+			return append([]WorkersKVNamespace{}, namespaces...), &ResultInfo{}, err
 		}
 
 		err = json.Unmarshal(res, &nsResponse)
 		if err != nil {
-			return []WorkersKVNamespace{}, &ResultInfo{}, fmt.Errorf("failed to unmarshal workers KV namespaces JSON data: %w", err)
+			return append([]WorkersKVNamespace{}, namespaces...), &ResultInfo{}, fmt.Errorf("failed to unmarshal workers KV namespaces JSON data: %w", err)
 		}
 
 		namespaces = append(namespaces, nsResponse.Result...)
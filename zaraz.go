@@ -363,12 +363,12 @@ func (api *API) ListZarazConfigHistory(ctx context.Context, rc *ResourceContaine
 		uri := buildURI(fmt.Sprintf("/zones/%s/settings/zaraz/v2/history", rc.Identifier), params)
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []ZarazHistoryRecord{}, &ResultInfo{}, err
+			return append([]ZarazHistoryRecord{}, records...), &ResultInfo{}, err
 		}
 		var listResponse ZarazConfigHistoryListResponse
 		err = json.Unmarshal(res, &listResponse)
 		if err != nil {
-			return []ZarazHistoryRecord{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return append([]ZarazHistoryRecord{}, records...), &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 		records = append(records, listResponse.Result...)
 		lastResultInfo = listResponse.ResultInfo
@@ -76,14 +76,19 @@ func (s *ZonesService) Get(ctx context.Context, rc *ResourceContainer) (Zone, er
 
 // List returns all zones that match the provided `ZoneParams` struct.
 //
-// Pagination is automatically handled unless `params.Page` is supplied.
+// Pagination is automatically handled unless `params.Page` is supplied. If a
+// page fails, the zones of the pages fetched so far are returned along with
+// the error.
 //
 // API reference: https://api.cloudflare.com/#zone-list-zones
 func (s *ZonesService) List(ctx context.Context, params *ZoneListParams) ([]Zone, *ResultInfo, error) {
-	res, _ := s.client.get(ctx, buildURI("/zones", params), nil)
+	res, err := s.client.get(ctx, buildURI("/zones", params), nil)
+	if err != nil {
+		return []Zone{}, &ResultInfo{}, err
+	}
 
 	var r ZonesResponse
-	err := json.Unmarshal(res, &r)
+	err = json.Unmarshal(res, &r)
 	if err != nil {
 		return []Zone{}, &ResultInfo{}, fmt.Errorf("failed to unmarshal zone JSON data: %w", err)
 	}
@@ -92,17 +97,23 @@ func (s *ZonesService) List(ctx context.Context, params *ZoneListParams) ([]Zone
 		var zones []Zone
 		params.PerPage = defaultZonesPerPage
 		params.Page = 1
-		for !params.ResultInfo.Done() {
-			res, _ := s.client.get(ctx, buildURI("/zones", params), nil)
+		for {
+			res, err := s.client.get(ctx, buildURI("/zones", params), nil)
+			if err != nil {
+				return append([]Zone{}, zones...), &ResultInfo{}, err
+			}
 
 			var zResponse ZonesResponse
-			err := json.Unmarshal(res, &zResponse)
+			err = json.Unmarshal(res, &zResponse)
 			if err != nil {
-				return []Zone{}, &ResultInfo{}, fmt.Errorf("failed to unmarshal zone JSON data: %w", err)
+				return append([]Zone{}, zones...), &ResultInfo{}, fmt.Errorf("failed to unmarshal zone JSON data: %w", err)
 			}
 
 			zones = append(zones, zResponse.Result...)
 
+			if !zResponse.ResultInfo.HasMorePages() {
+				break
+			}
 			params.ResultInfo = zResponse.ResultInfo.Next()
 		}
 		r.Result = zones
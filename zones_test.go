@@ -0,0 +1,83 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZonesService_ListPaginationPartialResults(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "", "1":
+			fmt.Fprint(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": [
+    {"id": "023e105f4ecef8ad9ca31a8372d0c353", "name": "example.com"},
+    {"id": "023e105f4ecef8ad9ca31a8372d0c354", "name": "example.net"}
+  ],
+  "result_info": {"page": 1, "per_page": 2, "count": 2, "total_count": 4, "total_pages": 2}
+}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"success": false, "errors": [{"code": 1000, "message": "page unavailable"}], "messages": [], "result": null}`)
+		}
+	})
+
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	experimental, err := NewExperimental(&ClientParams{Token: "deadbeef", BaseURL: baseURL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	zones, _, err := experimental.Zones.List(context.Background(), &ZoneListParams{})
+	assert.Error(t, err)
+	if assert.Len(t, zones, 2, "zones of the first page should be returned with the error") {
+		assert.Equal(t, "example.com", zones[0].Name)
+		assert.Equal(t, "example.net", zones[1].Name)
+	}
+}
+
+func TestZonesService_ListPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+  "success": true,
+  "errors": [],
+  "messages": [],
+  "result": [{"name": "page-%s-a.com"}, {"name": "page-%s-b.com"}],
+  "result_info": {"page": %s, "per_page": 2, "count": 2, "total_count": 4, "total_pages": 2}
+}`, page, page, page)
+	})
+
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	experimental, err := NewExperimental(&ClientParams{Token: "deadbeef", BaseURL: baseURL, HTTPClient: server.Client()})
+	require.NoError(t, err)
+
+	zones, _, err := experimental.Zones.List(context.Background(), &ZoneListParams{})
+	require.NoError(t, err)
+	names := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		names = append(names, zone.Name)
+	}
+	assert.Equal(t, []string{"page-1-a.com", "page-1-b.com", "page-2-a.com", "page-2-b.com"}, names)
+}